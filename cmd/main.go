@@ -2,14 +2,34 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	server "qrpay-wpp/internal/api"
+	"strings"
 )
 
 func main() {
 	var settingsPath string
+	var keyName string
+	var keyAccounts string
+	var keyAdmin bool
 	flag.StringVar(&settingsPath, "settings", "./configs/settings.json", "Path to settings file")
+	flag.StringVar(&keyName, "create-api-key", "", "Create an API key with this name, print it and exit")
+	flag.StringVar(&keyAccounts, "accounts", "", "Comma separated accounts of the created API key, * for all")
+	flag.BoolVar(&keyAdmin, "admin", false, "Allow the created API key to call the admin service")
 	flag.Parse()
 	s := server.New(settingsPath)
+	if keyName != "" {
+		var accounts []string
+		if keyAccounts != "" {
+			accounts = strings.Split(keyAccounts, ",")
+		}
+		key, err := s.CreateAPIKey(keyName, accounts, keyAdmin)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(key)
+		return
+	}
 	err := s.Start()
 	if err != nil {
 		panic(err)
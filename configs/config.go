@@ -19,9 +19,190 @@ type Database struct {
 	Name     string `json:"name"`
 }
 
+type Flow struct {
+	DefinitionsPath string `json:"definitions_path"`
+	SessionTTL      int    `json:"session_ttl"`
+}
+
+type Consent struct {
+	OptOutKeywords []string `json:"opt_out_keywords"`
+	OptOutReply    string   `json:"opt_out_reply"`
+}
+
+type Webhook struct {
+	MaxAttempts int `json:"max_attempts"`
+	Timeout     int `json:"timeout"`
+	Backoff     int `json:"backoff"`
+}
+
+type Outbox struct {
+	MaxAttempts int `json:"max_attempts"`
+	Backoff     int `json:"backoff"`
+}
+
+// RateLimit paces the messages sent by an account. Zero values disable the
+// respective limit. Delays are in milliseconds.
+type RateLimit struct {
+	PerMinute            float64 `json:"per_minute"`
+	Burst                int     `json:"burst"`
+	RecipientPerMinute   float64 `json:"recipient_per_minute"`
+	RecipientBurst       int     `json:"recipient_burst"`
+	DailyCap             int     `json:"daily_cap"`
+	MinDelay             int     `json:"min_delay"`
+	MaxDelay             int     `json:"max_delay"`
+	TypingCharsPerSecond int     `json:"typing_chars_per_second"`
+	MaxTyping            int     `json:"max_typing"`
+}
+
+// RateLimits holds the default limits and per account overrides, which replace the default entirely.
+type RateLimits struct {
+	Default  RateLimit            `json:"default"`
+	Accounts map[string]RateLimit `json:"accounts"`
+}
+
+// WarmupStage limits the daily sends of accounts younger than Days.
+type WarmupStage struct {
+	Days       int `json:"days"`
+	DailyLimit int `json:"daily_limit"`
+}
+
+type WarmupPolicy struct {
+	Name   string        `json:"name"`
+	Stages []WarmupStage `json:"stages"`
+}
+
+type Warmup struct {
+	DefaultPolicy string            `json:"default_policy"`
+	Accounts      map[string]string `json:"accounts"`
+	Policies      []WarmupPolicy    `json:"policies"`
+}
+
+// Sessions configures how paired sessions are restored at startup. Stagger is in milliseconds.
+type Sessions struct {
+	RestoreConcurrency int `json:"restore_concurrency"`
+	RestoreStagger     int `json:"restore_stagger"`
+}
+
+// Reconnect configures the backoff between reconnect attempts. Backoffs are in
+// milliseconds and Jitter is the fraction by which each delay is randomized.
+type Reconnect struct {
+	InitialBackoff int     `json:"initial_backoff"`
+	MaxBackoff     int     `json:"max_backoff"`
+	Multiplier     float64 `json:"multiplier"`
+	Jitter         float64 `json:"jitter"`
+}
+
+// Reconcile configures how often, in seconds, persisted connection flags are compared with live connections.
+type Reconcile struct {
+	Interval int `json:"interval"`
+}
+
+// Cluster identifies this node among the replicas. NodeID defaults to the host
+// name and process id, Address, where other nodes forward requests to, to the
+// host name and server port. Intervals are in seconds.
+type Cluster struct {
+	NodeID          string `json:"node_id"`
+	Address         string `json:"address"`
+	BalanceInterval int    `json:"balance_interval"`
+	LeaseTTL        int    `json:"lease_ttl"`
+}
+
+// Bus selects the event bus, "memory" for a single node or "postgres" to share
+// events among nodes over LISTEN/NOTIFY on Channel.
+type Bus struct {
+	Driver  string `json:"driver"`
+	Channel string `json:"channel"`
+}
+
+// Dispatch configures the asynchronous handling of WhatsApp events. QueueSize
+// bounds the events queued per account, Overflow is "block", "drop_newest" or "drop_oldest".
+// The drop policies never drop the lifecycle events of a session, such as Connected.
+type Dispatch struct {
+	Workers   int    `json:"workers"`
+	QueueSize int    `json:"queue_size"`
+	Overflow  string `json:"overflow"`
+}
+
+// Auth configures the authentication of RPCs with API keys and JWTs. JWTs are
+// verified with the keys of the JWKS file and scoped to the accounts listed in
+// AccountsClaim; those carrying AdminScope in their scope may also call the admin
+// service. KeyCacheTTL is in seconds.
+type Auth struct {
+	Enabled       bool   `json:"enabled"`
+	JWKSPath      string `json:"jwks_path"`
+	Issuer        string `json:"issuer"`
+	Audience      string `json:"audience"`
+	AccountsClaim string `json:"accounts_claim"`
+	AdminScope    string `json:"admin_scope"`
+	KeyCacheTTL   int    `json:"key_cache_ttl"`
+}
+
+// TLS configures the certificates of the gRPC server, read from the keys path
+// of the server. ClientAuth is "none", "request" or "require"; client
+// certificates are verified against ClientCAFile, and ClientsFile maps their
+// identities to accounts. ReloadInterval is in seconds.
+type TLS struct {
+	Enabled        bool   `json:"enabled"`
+	CertFile       string `json:"cert_file"`
+	KeyFile        string `json:"key_file"`
+	ClientAuth     string `json:"client_auth"`
+	ClientCAFile   string `json:"client_ca_file"`
+	ClientsFile    string `json:"clients_file"`
+	ReloadInterval int    `json:"reload_interval"`
+}
+
+// Gateway configures the HTTP/JSON API served next to the gRPC server. It is
+// disabled by default, enabling it requires a pairing secret.
+type Gateway struct {
+	Enabled bool `json:"enabled"`
+	Port    int  `json:"port"`
+}
+
+// Pairing configures the links of the pairing page. Secret signs the links, must
+// be shared by every node and is required when the gateway is enabled; LinkTTL
+// is in seconds and BaseURL is the public address of the gateway.
+type Pairing struct {
+	Secret  string `json:"secret"`
+	LinkTTL int    `json:"link_ttl"`
+	BaseURL string `json:"base_url"`
+}
+
+// QR holds the defaults of the QR codes rendered by the QR RPC. Size is in
+// pixels, Margin in modules and ErrorCorrection one of low, medium, high or highest.
+type QR struct {
+	Size            int    `json:"size"`
+	Margin          int    `json:"margin"`
+	ErrorCorrection string `json:"error_correction"`
+}
+
+// Shutdown bounds, in seconds, the wait for the ongoing RPCs and then for the
+// queued sends when the server is stopped.
+type Shutdown struct {
+	GracePeriod  int `json:"grace_period"`
+	DrainTimeout int `json:"drain_timeout"`
+}
+
 type Config struct {
-	Server   Server   `json:"server"`
-	Database Database `json:"db"`
+	Server    Server     `json:"server"`
+	Database  Database   `json:"db"`
+	Flow      Flow       `json:"flow"`
+	Consent   Consent    `json:"consent"`
+	Webhook   Webhook    `json:"webhook"`
+	Outbox    Outbox     `json:"outbox"`
+	RateLimit RateLimits `json:"rate_limit"`
+	Warmup    Warmup     `json:"warmup"`
+	Sessions  Sessions   `json:"sessions"`
+	Reconnect Reconnect  `json:"reconnect"`
+	Reconcile Reconcile  `json:"reconcile"`
+	Cluster   Cluster    `json:"cluster"`
+	Bus       Bus        `json:"bus"`
+	Dispatch  Dispatch   `json:"dispatch"`
+	Auth      Auth       `json:"auth"`
+	TLS       TLS        `json:"tls"`
+	Gateway   Gateway    `json:"gateway"`
+	Pairing   Pairing    `json:"pairing"`
+	QR        QR         `json:"qr"`
+	Shutdown  Shutdown   `json:"shutdown"`
 }
 
 var instance *Config
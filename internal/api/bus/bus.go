@@ -0,0 +1,50 @@
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Message is an event published on the bus. Data holds the JSON encoded event.
+type Message struct {
+	Topic       string          `json:"topic"`
+	Type        string          `json:"type"`
+	AccountUUID string          `json:"account_uuid"`
+	Node        string          `json:"node"`
+	At          time.Time       `json:"at"`
+	Data        json.RawMessage `json:"data,omitempty"`
+	Truncated   bool            `json:"truncated,omitempty"`
+}
+
+// Bus decouples the components producing events from the ones consuming them.
+// Subscribers of a topic receive every message published on it, from any node
+// sharing the bus. Slow subscribers miss messages instead of blocking publishers.
+type Bus interface {
+	Publish(ctx context.Context, msg *Message) error
+	Subscribe(topic string) (<-chan *Message, func())
+	Close()
+}
+
+// NewMessage encodes data into a message for the topic.
+func NewMessage(topic string, typ string, accountUUID string, data any) (*Message, error) {
+	msg := &Message{
+		Topic:       topic,
+		Type:        typ,
+		AccountUUID: accountUUID,
+		At:          time.Now().UTC(),
+	}
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		msg.Data = b
+	}
+	return msg, nil
+}
+
+// Decode unmarshals the data of the message into v.
+func (m *Message) Decode(v any) error {
+	return json.Unmarshal(m.Data, v)
+}
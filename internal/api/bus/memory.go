@@ -0,0 +1,92 @@
+package bus
+
+import (
+	"context"
+	"sync"
+)
+
+const subscriberBuffer = 256
+
+type memory struct {
+	node string
+
+	mu          sync.RWMutex
+	subscribers map[string]map[chan *Message]struct{}
+	closed      bool
+}
+
+// NewMemory returns a bus delivering messages within this process only.
+func NewMemory(node string) Bus {
+	return newMemory(node)
+}
+
+func newMemory(node string) *memory {
+	return &memory{
+		node:        node,
+		subscribers: map[string]map[chan *Message]struct{}{},
+	}
+}
+
+func (b *memory) Publish(ctx context.Context, msg *Message) error {
+	if msg.Node == "" {
+		msg.Node = b.node
+	}
+	b.dispatch(msg)
+	return nil
+}
+
+func (b *memory) dispatch(msg *Message) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subscribers[msg.Topic] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+func (b *memory) Subscribe(topic string) (<-chan *Message, func()) {
+	ch := make(chan *Message, subscriberBuffer)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = map[chan *Message]struct{}{}
+	}
+	b.subscribers[topic][ch] = struct{}{}
+	once := sync.Once{}
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subscribers[topic][ch]; !ok {
+				return
+			}
+			delete(b.subscribers[topic], ch)
+			if len(b.subscribers[topic]) == 0 {
+				delete(b.subscribers, topic)
+			}
+			close(ch)
+		})
+	}
+}
+
+// Close ends every subscription, closing their channels.
+func (b *memory) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for topic, subs := range b.subscribers {
+		for ch := range subs {
+			close(ch)
+		}
+		delete(b.subscribers, topic)
+	}
+}
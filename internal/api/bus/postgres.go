@@ -0,0 +1,137 @@
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+// maxPayload stays below the 8000 bytes Postgres accepts in a notification.
+const maxPayload = 7900
+
+const (
+	listenMinBackoff = time.Second
+	listenMaxBackoff = 30 * time.Second
+)
+
+type postgres struct {
+	*memory
+	pool    *pgxpool.Pool
+	channel string
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// NewPostgres returns a bus shared by every node connected to the database,
+// built on LISTEN/NOTIFY over the given channel. Messages are delivered to
+// local subscribers when their notification comes back, so every node,
+// including the publisher, sees them once.
+func NewPostgres(ctx context.Context, pool *pgxpool.Pool, channel string, node string) (Bus, error) {
+	conn, err := listen(ctx, pool, channel)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	b := &postgres{
+		memory:  newMemory(node),
+		pool:    pool,
+		channel: channel,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+	go b.run(ctx, conn)
+	return b, nil
+}
+
+// listen takes a connection out of the pool, as it stays busy waiting for notifications.
+func listen(ctx context.Context, pool *pgxpool.Pool, channel string) (*pgx.Conn, error) {
+	c, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn := c.Hijack()
+	_, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize())
+	if err != nil {
+		conn.Close(ctx)
+		return nil, err
+	}
+	return conn, nil
+}
+
+// Publish notifies every node. Messages whose data do not fit in a
+// notification are published without it and marked as truncated.
+func (b *postgres) Publish(ctx context.Context, msg *Message) error {
+	if msg.Node == "" {
+		msg.Node = b.node
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxPayload {
+		truncated := *msg
+		truncated.Data = nil
+		truncated.Truncated = true
+		payload, err = json.Marshal(&truncated)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.pool.Exec(ctx, `SELECT pg_notify($1, $2)`, b.channel, string(payload))
+	return err
+}
+
+func (b *postgres) run(ctx context.Context, conn *pgx.Conn) {
+	defer close(b.done)
+	backoff := listenMinBackoff
+	for {
+		if conn == nil {
+			var err error
+			conn, err = listen(ctx, b.pool, b.channel)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				fmt.Printf("Bus listen failed: %v\n", err)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+				backoff *= 2
+				if backoff > listenMaxBackoff {
+					backoff = listenMaxBackoff
+				}
+				continue
+			}
+			backoff = listenMinBackoff
+		}
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			conn.Close(context.Background())
+			conn = nil
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Printf("Bus connection lost: %v\n", err)
+			continue
+		}
+		msg := &Message{}
+		err = json.Unmarshal([]byte(n.Payload), msg)
+		if err != nil {
+			fmt.Printf("Bus message discarded: %v\n", err)
+			continue
+		}
+		b.dispatch(msg)
+	}
+}
+
+// Close stops listening and ends every subscription.
+func (b *postgres) Close() {
+	b.cancel()
+	<-b.done
+	b.memory.Close()
+}
@@ -0,0 +1,294 @@
+package certs
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"qrpay-wpp/configs"
+	"qrpay-wpp/internal/api/model"
+	"sync"
+	"time"
+)
+
+// Client authentication policies.
+const (
+	ClientAuthNone    = "none"
+	ClientAuthRequest = "request"
+	ClientAuthRequire = "require"
+)
+
+const (
+	defaultCertFile       = "server.crt"
+	defaultKeyFile        = "server.key"
+	defaultReloadInterval = time.Minute
+)
+
+// Client maps the identity of a client certificate, its common name or one of
+// its DNS or URI names, to the accounts it may act on.
+type Client struct {
+	Identity string   `json:"identity"`
+	Accounts []string `json:"accounts"`
+	Admin    bool     `json:"admin"`
+}
+
+// Store holds the certificates read from the keys path and reloads them when
+// their files change, so that they can be renewed without a restart.
+type Store struct {
+	config   configs.TLS
+	path     func(name string) string
+	interval time.Duration
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+	clients  map[string]Client
+	modTimes map[string]time.Time
+}
+
+// New loads the certificates of the keys path. File names of the config are
+// relative to it.
+func New(config configs.TLS, keysPath string) (*Store, error) {
+	if config.CertFile == "" {
+		config.CertFile = defaultCertFile
+	}
+	if config.KeyFile == "" {
+		config.KeyFile = defaultKeyFile
+	}
+	if config.ClientAuth == "" {
+		config.ClientAuth = ClientAuthNone
+	}
+	if config.ClientAuth != ClientAuthNone && config.ClientCAFile == "" {
+		return nil, errors.New("client authentication requires a client CA file")
+	}
+	s := &Store{
+		config:   config,
+		interval: time.Duration(config.ReloadInterval) * time.Second,
+		modTimes: map[string]time.Time{},
+		path: func(name string) string {
+			if filepath.IsAbs(name) {
+				return name
+			}
+			return filepath.Join(keysPath, name)
+		},
+	}
+	if s.interval <= 0 {
+		s.interval = defaultReloadInterval
+	}
+	err := s.reload()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// changed reports whether the files were modified since they were last loaded.
+func (s *Store) changed(names ...string) bool {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		info, err := os.Stat(s.path(name))
+		if err != nil || !info.ModTime().Equal(s.modTimes[name]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Store) record(names ...string) {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if info, err := os.Stat(s.path(name)); err == nil {
+			s.modTimes[name] = info.ModTime()
+		}
+	}
+}
+
+// reload reads the files that changed. A file that fails to load keeps its
+// previous version in use.
+func (s *Store) reload() error {
+	c := s.config
+	if s.cert == nil || s.changed(c.CertFile, c.KeyFile) {
+		cert, err := tls.LoadX509KeyPair(s.path(c.CertFile), s.path(c.KeyFile))
+		if err != nil {
+			return fmt.Errorf("unable to load server certificate: %v", err)
+		}
+		s.mu.Lock()
+		s.cert = &cert
+		s.record(c.CertFile, c.KeyFile)
+		s.mu.Unlock()
+	}
+	if c.ClientCAFile != "" && (s.clientCA == nil || s.changed(c.ClientCAFile)) {
+		b, err := os.ReadFile(s.path(c.ClientCAFile))
+		if err != nil {
+			return fmt.Errorf("unable to read client CA: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(b) {
+			return fmt.Errorf("no certificate found in client CA %s", c.ClientCAFile)
+		}
+		s.mu.Lock()
+		s.clientCA = pool
+		s.record(c.ClientCAFile)
+		s.mu.Unlock()
+	}
+	if c.ClientsFile != "" && (s.clients == nil || s.changed(c.ClientsFile)) {
+		b, err := os.ReadFile(s.path(c.ClientsFile))
+		if err != nil {
+			return fmt.Errorf("unable to read clients: %v", err)
+		}
+		var list []Client
+		err = json.Unmarshal(b, &list)
+		if err != nil {
+			return fmt.Errorf("invalid clients file %s: %v", c.ClientsFile, err)
+		}
+		clients := make(map[string]Client, len(list))
+		for _, client := range list {
+			clients[client.Identity] = client
+		}
+		s.mu.Lock()
+		s.clients = clients
+		s.record(c.ClientsFile)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// Start reloads the changed files periodically until ctx is done.
+func (s *Store) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.reload()
+			if err != nil {
+				fmt.Printf("Unable to reload certificates: %v\n", err)
+			}
+		}
+	}
+}
+
+func (s *Store) certificate() *tls.Certificate {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cert
+}
+
+func (s *Store) clientAuth() tls.ClientAuthType {
+	switch s.config.ClientAuth {
+	case ClientAuthRequest:
+		return tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		return tls.RequireAndVerifyClientCert
+	default:
+		return tls.NoClientCert
+	}
+}
+
+// ServerConfig returns the TLS config of the server, which always uses the
+// certificates loaded last.
+func (s *Store) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			s.mu.RLock()
+			defer s.mu.RUnlock()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*s.cert},
+				ClientAuth:   s.clientAuth(),
+				ClientCAs:    s.clientCA,
+				NextProtos:   []string{"h2"},
+			}, nil
+		},
+	}
+}
+
+// GatewayConfig returns the TLS config of the HTTP gateway, which serves the
+// same certificate over HTTP/2 and HTTP/1.1. Gateway callers authenticate with
+// tokens, so no client certificate is asked for.
+func (s *Store) GatewayConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return s.certificate(), nil
+		},
+	}
+}
+
+// ClientConfig returns the TLS config used to reach other nodes. It trusts the
+// client CA, or the system roots without one, and presents the certificate of
+// this node, which must then allow client authentication too. The calls made
+// with it carry a bearer token, as the certificate identifies no caller.
+func (s *Store) ClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The server is verified below, against the CA loaded last
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("no server certificate")
+			}
+			s.mu.RLock()
+			roots := s.clientCA
+			s.mu.RUnlock()
+			opts := x509.VerifyOptions{
+				Roots:         roots,
+				DNSName:       cs.ServerName,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return s.certificate(), nil
+		},
+	}
+}
+
+// identities returns the names a certificate may be mapped by.
+func identities(cert *x509.Certificate) []string {
+	names := []string{cert.Subject.CommonName}
+	names = append(names, cert.DNSNames...)
+	for _, u := range cert.URIs {
+		names = append(names, u.String())
+	}
+	return names
+}
+
+// AuthenticatePeer returns the caller identified by a verified client
+// certificate, nil when the certificate is not mapped to any account. The
+// certificate of the node itself, presented by its proxy hops, identifies no caller.
+func (s *Store) AuthenticatePeer(cert *x509.Certificate) *model.Principal {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.cert.Certificate) > 0 && bytes.Equal(cert.Raw, s.cert.Certificate[0]) {
+		return nil
+	}
+	for _, name := range identities(cert) {
+		if client, ok := s.clients[name]; ok && name != "" {
+			return &model.Principal{
+				Subject:  name,
+				Method:   "mtls",
+				Accounts: client.Accounts,
+				Admin:    client.Admin,
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Option maps a customer input to the next step of the flow.
+type Option struct {
+	Input string `json:"input"`
+	Next  string `json:"next"`
+}
+
+const (
+	// ActionHandoff hands the conversation over to a human agent.
+	ActionHandoff = "handoff"
+)
+
+// Step is a single state of a flow. Steps without options end the flow.
+type Step struct {
+	Text    string   `json:"text"`
+	Hook    string   `json:"hook"`
+	Action  string   `json:"action"`
+	Options []Option `json:"options"`
+	Invalid string   `json:"invalid"`
+}
+
+// Definition is a declarative flow loaded from the flow definitions file.
+type Definition struct {
+	Name     string          `json:"name"`
+	Triggers []string        `json:"triggers"`
+	Start    string          `json:"start"`
+	Error    string          `json:"error"`
+	Steps    map[string]Step `json:"steps"`
+}
+
+type definitions struct {
+	Flows []*Definition `json:"flows"`
+}
+
+func Load(path string) ([]*Definition, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	defs := &definitions{}
+	err = json.NewDecoder(file).Decode(defs)
+	if err != nil {
+		return nil, err
+	}
+	for _, def := range defs.Flows {
+		err = def.validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return defs.Flows, nil
+}
+
+func (d *Definition) validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("flow without name")
+	}
+	if _, ok := d.Steps[d.Start]; !ok {
+		return fmt.Errorf("flow %s: start step %q not found", d.Name, d.Start)
+	}
+	for name, step := range d.Steps {
+		if step.Action != "" && step.Action != ActionHandoff {
+			return fmt.Errorf("flow %s: step %s has unknown action %q", d.Name, name, step.Action)
+		}
+		for _, option := range step.Options {
+			if _, ok := d.Steps[option.Next]; !ok {
+				return fmt.Errorf("flow %s: step %s points to unknown step %q", d.Name, name, option.Next)
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Definition) triggeredBy(input string) bool {
+	for _, trigger := range d.Triggers {
+		if normalize(trigger) == input {
+			return true
+		}
+	}
+	return false
+}
+
+func normalize(input string) string {
+	return strings.ToLower(strings.TrimSpace(input))
+}
@@ -0,0 +1,168 @@
+package flow
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"qrpay-wpp/internal/api/model"
+	"text/template"
+)
+
+// HookRequest is passed to a Hook when a flow enters a step that declares one.
+type HookRequest struct {
+	AccountUUID string
+	Phone       string
+	Flow        string
+	Step        string
+	Input       string
+	Data        map[string]string
+}
+
+// Hook lets a flow step call the backend. The returned values are merged into
+// the session data and are available to the step text as template fields.
+type Hook interface {
+	Call(ctx context.Context, req *HookRequest) (map[string]string, error)
+}
+
+type HookFunc func(ctx context.Context, req *HookRequest) (map[string]string, error)
+
+func (f HookFunc) Call(ctx context.Context, req *HookRequest) (map[string]string, error) {
+	return f(ctx, req)
+}
+
+// Result is the outcome of feeding a customer input to the engine.
+type Result struct {
+	Handled bool
+	Done    bool
+	Handoff bool
+	Replies []string
+}
+
+type Engine struct {
+	flows []*Definition
+	hooks map[string]Hook
+}
+
+func NewEngine(flows []*Definition, hooks map[string]Hook) *Engine {
+	if hooks == nil {
+		hooks = map[string]Hook{}
+	}
+	return &Engine{
+		flows: flows,
+		hooks: hooks,
+	}
+}
+
+func (e *Engine) getFlow(name string) *Definition {
+	for _, def := range e.flows {
+		if def.Name == name {
+			return def
+		}
+	}
+	return nil
+}
+
+// Handle advances the session with the given input. A session with an empty
+// Flow starts the first flow triggered by the input, if any.
+func (e *Engine) Handle(ctx context.Context, session *model.FlowSession, input string) (*Result, error) {
+	input = normalize(input)
+	if session.Data == nil {
+		session.Data = map[string]string{}
+	}
+
+	def := e.getFlow(session.Flow)
+	if def == nil {
+		for _, d := range e.flows {
+			if d.triggeredBy(input) {
+				def = d
+				break
+			}
+		}
+		if def == nil {
+			return &Result{}, nil
+		}
+		session.Flow = def.Name
+		return e.enter(ctx, def, session, def.Start, input)
+	}
+
+	step, ok := def.Steps[session.Step]
+	if !ok {
+		return e.enter(ctx, def, session, def.Start, input)
+	}
+	for _, option := range step.Options {
+		if normalize(option.Input) == input {
+			return e.enter(ctx, def, session, option.Next, input)
+		}
+	}
+	reply := step.Invalid
+	if reply == "" {
+		reply = step.Text
+	}
+	text, err := render(reply, session.Data)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Handled: true, Replies: []string{text}}, nil
+}
+
+func (e *Engine) enter(ctx context.Context, def *Definition, session *model.FlowSession, name string, input string) (*Result, error) {
+	step := def.Steps[name]
+	session.Step = name
+
+	if step.Hook != "" {
+		hook, ok := e.hooks[step.Hook]
+		if !ok {
+			return e.fail(def, fmt.Errorf("flow %s: hook %q not registered", def.Name, step.Hook))
+		}
+		req := &HookRequest{
+			AccountUUID: session.AccountUUID,
+			Phone:       session.Phone,
+			Flow:        def.Name,
+			Step:        name,
+			Input:       input,
+			Data:        session.Data,
+		}
+		values, err := hook.Call(ctx, req)
+		if err != nil {
+			return e.fail(def, err)
+		}
+		for k, v := range values {
+			session.Data[k] = v
+		}
+	}
+
+	text, err := render(step.Text, session.Data)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{
+		Handled: true,
+		Done:    len(step.Options) == 0,
+		Handoff: step.Action == ActionHandoff,
+	}
+	if text != "" {
+		res.Replies = []string{text}
+	}
+	return res, nil
+}
+
+func (e *Engine) fail(def *Definition, err error) (*Result, error) {
+	if def.Error == "" {
+		return nil, err
+	}
+	fmt.Printf("Flow error: %v\n", err)
+	return &Result{Handled: true, Done: true, Replies: []string{def.Error}}, nil
+}
+
+func render(text string, data map[string]string) (string, error) {
+	tmpl, err := template.New("step").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	b := new(bytes.Buffer)
+	err = tmpl.Execute(b, data)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,147 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	_ "embed"
+	"fmt"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"net"
+	"net/http"
+	"qrpay-wpp/internal/api/interceptor"
+	proto "qrpay-wpp/internal/api/proto/generated"
+	"strings"
+	"time"
+)
+
+// openAPI is generated by protoc-gen-openapiv2 from whatsapp.proto and gateway.yaml.
+//
+//go:embed whatsapp.swagger.json
+var openAPI []byte
+
+// eventStream is the media type requesting streams as server-sent events.
+const eventStream = "text/event-stream"
+
+// eventStreamMarshaler writes every message of a stream as a server-sent event.
+type eventStreamMarshaler struct {
+	runtime.JSONPb
+}
+
+func (m *eventStreamMarshaler) ContentType(any) string {
+	return eventStream
+}
+
+func (m *eventStreamMarshaler) Marshal(v any) ([]byte, error) {
+	b, err := m.JSONPb.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("data: "), b...), nil
+}
+
+func (m *eventStreamMarshaler) Delimiter() []byte {
+	return []byte("\n\n")
+}
+
+// headerMatcher passes the API key header on to the gRPC server, along with
+// the headers passed by default such as authorization.
+func headerMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, "X-Api-Key") {
+		return "x-api-key", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// markUnary and markStream mark the calls of the gateway as proxied, so that the
+// certificate of the node is never taken for the identity of the HTTP caller.
+func markUnary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, interceptor.ProxyHeader, "gateway")
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func markStream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, interceptor.ProxyHeader, "gateway")
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+// Gateway serves an HTTP/JSON API transcoded to the gRPC services. Streams are
+// sent as newline delimited JSON, or as server-sent events when the client
+// accepts text/event-stream.
+type Gateway struct {
+	server *http.Server
+	conn   *grpc.ClientConn
+}
+
+// New creates a gateway calling the gRPC server at grpcAddress with creds. It
+// serves over TLS when tlsConfig is not nil, and the pairing page when pairing
+// is not nil.
+func New(ctx context.Context, grpcAddress string, creds credentials.TransportCredentials, port int, tlsConfig *tls.Config, pairing PairingVerifier) (*Gateway, error) {
+	conn, err := grpc.DialContext(ctx, grpcAddress,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(markUnary),
+		grpc.WithChainStreamInterceptor(markStream),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial gRPC server: %v", err)
+	}
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(eventStream, &eventStreamMarshaler{}),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+	)
+	err = proto.RegisterWhatsAppServiceHandler(ctx, mux, conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to register gateway: %v", err)
+	}
+
+	root := http.NewServeMux()
+	root.Handle("/v1/", mux)
+	if pairing != nil {
+		root.Handle(pairingPrefix, &pairingPage{client: proto.NewWhatsAppServiceClient(conn), verifier: pairing})
+	}
+	root.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(openAPI)
+	})
+	return &Gateway{
+		server: &http.Server{
+			Addr:              fmt.Sprintf(":%d", port),
+			Handler:           root,
+			TLSConfig:         tlsConfig,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		conn: conn,
+	}, nil
+}
+
+// Serve accepts requests until the gateway is shut down.
+func (g *Gateway) Serve() error {
+	listener, err := net.Listen("tcp", g.server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+	fmt.Printf("HTTP gateway listening at %s\n", listener.Addr())
+	if g.server.TLSConfig != nil {
+		err = g.server.ServeTLS(listener, "", "")
+	} else {
+		err = g.server.Serve(listener)
+	}
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops accepting requests and waits for the ongoing ones until ctx is
+// done, then closes the remaining ones, such as event streams.
+func (g *Gateway) Shutdown(ctx context.Context) error {
+	err := g.server.Shutdown(ctx)
+	if err != nil {
+		g.server.Close()
+	}
+	g.conn.Close()
+	return err
+}
@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	errs "github.com/cristiancll/go-errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"qrpay-wpp/configs"
+	"qrpay-wpp/internal/api/certs"
+	"qrpay-wpp/internal/api/interceptor"
+	"qrpay-wpp/internal/api/model"
+	proto "qrpay-wpp/internal/api/proto/generated"
+	"qrpay-wpp/internal/errCode"
+	"testing"
+	"time"
+)
+
+const testAccount = "0b7c5d4e-2f1a-4c3b-9d8e-7f6a5b4c3d2e"
+
+type tokenAuthenticator struct{}
+
+func (tokenAuthenticator) Authenticate(ctx context.Context, token string) (*model.Principal, error) {
+	if token != "valid" {
+		return nil, errs.New(errors.New("missing credentials"), errCode.Unauthenticated)
+	}
+	return &model.Principal{Subject: "client", Method: "api_key", Accounts: []string{model.AllAccounts}}, nil
+}
+
+type statusServer struct {
+	proto.UnimplementedWhatsAppServiceServer
+}
+
+func (statusServer) Status(ctx context.Context, req *proto.WhatsAppStatusRequest) (*proto.WhatsAppStatusResponse, error) {
+	return &proto.WhatsAppStatusResponse{State: "connected"}, nil
+}
+
+// writeNodeCertificate writes a self-signed certificate for localhost, usable
+// both as the server and the client certificate of the node.
+func writeNodeCertificate(t *testing.T, dir string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	files := map[string][]byte{
+		"server.crt": certPEM,
+		"ca.crt":     certPEM,
+		"server.key": pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		// The worst case: the identity of the node is mapped to an admin client
+		"clients.json": []byte(`[{"identity": "localhost", "accounts": ["*"], "admin": true}]`),
+	}
+	for name, b := range files {
+		err = os.WriteFile(filepath.Join(dir, name), b, 0600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestGatewayRequiresToken(t *testing.T) {
+	dir := t.TempDir()
+	writeNodeCertificate(t, dir)
+	store, err := certs.New(configs.TLS{
+		Enabled:      true,
+		ClientAuth:   certs.ClientAuthRequire,
+		ClientCAFile: "ca.crt",
+		ClientsFile:  "clients.json",
+	}, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	translator := interceptor.NewErrors()
+	auth := interceptor.NewAuth(tokenAuthenticator{}, store)
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(store.ServerConfig())),
+		grpc.ChainUnaryInterceptor(translator.Unary(), auth.Unary()),
+		grpc.ChainStreamInterceptor(translator.Stream(), auth.Stream()),
+	)
+	proto.RegisterWhatsAppServiceServer(server, statusServer{})
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(listener)
+	defer server.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gw, err := New(ctx, listener.Addr().String(), credentials.NewTLS(store.ClientConfig()), 0, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gw.conn.Close()
+	ts := httptest.NewServer(gw.server.Handler)
+	defer ts.Close()
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"without token", "", http.StatusUnauthorized},
+		{"with token", "valid", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, ts.URL+"/v1/accounts/"+testAccount+"/status", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			res, err := ts.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res.Body.Close()
+			if res.StatusCode != tt.status {
+				t.Errorf("got status %d, want %d", res.StatusCode, tt.status)
+			}
+		})
+	}
+}
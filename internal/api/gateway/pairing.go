@@ -0,0 +1,243 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"html/template"
+	"net/http"
+	proto "qrpay-wpp/internal/api/proto/generated"
+	"qrpay-wpp/internal/api/qr"
+	"strings"
+	"time"
+)
+
+const (
+	pairingPrefix = "/pair/"
+
+	// pairingPNGSize is the size, in pixels, of the QR codes served as PNG.
+	pairingPNGSize = 320
+	// pairingPollInterval is how often the page checks whether the account got paired.
+	pairingPollInterval = 2 * time.Second
+	// pairingQRTimeout bounds the wait for a QR code when serving it as an image.
+	pairingQRTimeout = 20 * time.Second
+)
+
+// PairingVerifier returns the account of a valid pairing token and its expiry.
+type PairingVerifier interface {
+	Verify(token string) (string, time.Time, error)
+}
+
+var pairingTemplate = template.Must(template.New("pairing").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Link WhatsApp</title>
+<style>
+body { font-family: sans-serif; display: flex; flex-direction: column; align-items: center; margin: 2rem; color: #222; }
+img { width: 320px; height: 320px; image-rendering: pixelated; }
+#status { margin-top: 1rem; }
+</style>
+</head>
+<body data-events="{{.Base}}/events">
+<h1>Link WhatsApp</h1>
+<p>Open WhatsApp on your phone, go to Linked devices and scan the code below.</p>
+<img id="qr" src="{{.Base}}/qr.png" alt="QR code">
+<p id="status">Waiting for the code to be scanned. This link expires at {{.ExpiresAt}}.</p>
+<script src="/pair/pairing.js"></script>
+</body>
+</html>
+`))
+
+const pairingScript = `(function () {
+  var qr = document.getElementById("qr");
+  var status = document.getElementById("status");
+  var events = new EventSource(document.body.dataset.events);
+  events.addEventListener("qr", function (e) {
+    qr.src = "data:image/svg+xml;base64," + btoa(e.data);
+  });
+  events.addEventListener("paired", function (e) {
+    events.close();
+    qr.style.display = "none";
+    status.textContent = "WhatsApp linked" + (e.data ? " to " + e.data : "") + ". You can close this page.";
+  });
+  events.addEventListener("expired", function () {
+    events.close();
+    qr.style.display = "none";
+    status.textContent = "This link expired, ask for a new one.";
+  });
+  events.addEventListener("failed", function (e) {
+    events.close();
+    status.textContent = "Unable to link WhatsApp: " + e.data;
+  });
+})();
+`
+
+// pairingPage serves the page merchants pair their number from. The signed
+// token of the link is its only credential: it is passed on to the gRPC server,
+// which only lets it connect the account and follow its QR code.
+type pairingPage struct {
+	client   proto.WhatsAppServiceClient
+	verifier PairingVerifier
+}
+
+func (p *pairingPage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, pairingPrefix)
+	if path == "pairing.js" {
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		_, _ = w.Write([]byte(pairingScript))
+		return
+	}
+	token, resource, _ := strings.Cut(path, "/")
+	accountUUID, expiresAt, err := p.verifier.Verify(token)
+	if err != nil {
+		http.Error(w, "This pairing link is invalid or expired.", http.StatusUnauthorized)
+		return
+	}
+	// The token is in the URL, keep it out of caches and referrers
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; script-src 'self'; style-src 'unsafe-inline'; img-src 'self' data:; connect-src 'self'")
+
+	ctx := metadata.AppendToOutgoingContext(r.Context(), "authorization", "Bearer "+token)
+	switch resource {
+	case "":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = pairingTemplate.Execute(w, map[string]string{
+			"Base":      pairingPrefix + token,
+			"ExpiresAt": expiresAt.Format(time.RFC1123),
+		})
+	case "qr.png", "qr.svg":
+		p.image(ctx, w, accountUUID, resource)
+	case "events":
+		p.events(ctx, w, accountUUID, expiresAt)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	http.Error(w, status.Convert(err).Message(), runtime.HTTPStatusFromCode(status.Code(err)))
+}
+
+// connect starts the session of the account and streams its QR codes, skipping
+// repeated and empty ones, until ctx is done.
+func (p *pairingPage) connect(ctx context.Context, accountUUID string) (<-chan string, error) {
+	_, err := p.client.Connect(ctx, &proto.WhatsAppConnectRequest{AccountUUID: accountUUID})
+	if err != nil {
+		return nil, err
+	}
+	stream, err := p.client.QR(ctx, &proto.WhatsAppQRRequest{AccountUUID: accountUUID})
+	if err != nil {
+		return nil, err
+	}
+	codes := make(chan string)
+	go func() {
+		defer close(codes)
+		var last string
+		for {
+			res, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if res.Qr == "" || res.Qr == last {
+				continue
+			}
+			last = res.Qr
+			select {
+			case codes <- res.Qr:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return codes, nil
+}
+
+func (p *pairingPage) image(ctx context.Context, w http.ResponseWriter, accountUUID string, resource string) {
+	ctx, cancel := context.WithTimeout(ctx, pairingQRTimeout)
+	defer cancel()
+	codes, err := p.connect(ctx, accountUUID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	code, ok := <-codes
+	if !ok {
+		http.Error(w, "No QR code available yet.", http.StatusServiceUnavailable)
+		return
+	}
+	if resource == "qr.svg" {
+		svg, err := qr.SVG(code, qr.Options{})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, _ = w.Write([]byte(svg))
+		return
+	}
+	png, err := qr.PNG(code, qr.Options{Size: pairingPNGSize})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	_, _ = w.Write(png)
+}
+
+// events sends every new QR code as a server-sent event, until the account is
+// paired or the link expires.
+func (p *pairingPage) events(ctx context.Context, w http.ResponseWriter, accountUUID string, expiresAt time.Time) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported.", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", eventStream)
+	w.WriteHeader(http.StatusOK)
+	send := func(event string, data string) {
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+		flusher.Flush()
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, expiresAt)
+	defer cancel()
+	codes, err := p.connect(ctx, accountUUID)
+	if err != nil {
+		send("failed", status.Convert(err).Message())
+		return
+	}
+	ticker := time.NewTicker(pairingPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				send("expired", "")
+			}
+			return
+		case code, ok := <-codes:
+			if !ok {
+				codes = nil
+				continue
+			}
+			svg, err := qr.SVG(code, qr.Options{})
+			if err != nil {
+				continue
+			}
+			send("qr", svg)
+		case <-ticker.C:
+			st, err := p.client.Status(ctx, &proto.WhatsAppStatusRequest{AccountUUID: accountUUID})
+			if err == nil && st.State == "connected" {
+				send("paired", st.Phone)
+				return
+			}
+		}
+	}
+}
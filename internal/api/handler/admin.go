@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"qrpay-wpp/internal/api/model"
+	proto "qrpay-wpp/internal/api/proto/generated"
+	"qrpay-wpp/internal/api/service"
+	"qrpay-wpp/internal/errCode"
+)
+
+type Admin interface {
+	proto.AdminServiceServer
+}
+
+type admin struct {
+	reconciler service.Reconciler
+	wpp        service.WhatsApp
+	auth       service.Auth
+	proto.UnimplementedAdminServiceServer
+}
+
+func NewAdmin(reconciler service.Reconciler, wpp service.WhatsApp, auth service.Auth) Admin {
+	return &admin{reconciler: reconciler, wpp: wpp, auth: auth}
+}
+
+func toProtoAPIKey(k *model.APIKey) *proto.AdminAPIKey {
+	res := &proto.AdminAPIKey{
+		Uuid:      k.UUID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Accounts:  k.Accounts,
+		Admin:     k.Admin,
+		CreatedAt: timestamppb.New(k.CreatedAt),
+	}
+	if k.LastUsedAt != nil {
+		res.LastUsedAt = timestamppb.New(*k.LastUsedAt)
+	}
+	if k.RevokedAt != nil {
+		res.RevokedAt = timestamppb.New(*k.RevokedAt)
+	}
+	return res
+}
+
+// Reconciliation returns the last reconciliation report, running one first when
+// asked to or when none ran yet.
+func (h *admin) Reconciliation(ctx context.Context, req *proto.AdminReconciliationRequest) (*proto.AdminReconciliationResponse, error) {
+	report := h.reconciler.Report()
+	if req.Run || report == nil {
+		var err error
+		report, err = h.reconciler.Reconcile(ctx)
+		if err != nil {
+			return nil, errCode.Wrap(err)
+		}
+	}
+	res := &proto.AdminReconciliationResponse{
+		CheckedAt:       timestamppb.New(report.CheckedAt),
+		FixedAccounts:   report.Fixed,
+		OrphanedDevices: report.OrphanedDevices,
+	}
+	for _, wpp := range report.OrphanedRows {
+		res.OrphanedAccounts = append(res.OrphanedAccounts, &proto.AdminOrphanedAccount{
+			AccountUUID: wpp.AccountUUID,
+			Phone:       wpp.Phone,
+		})
+	}
+	return res, nil
+}
+
+func (h *admin) Dispatch(ctx context.Context, req *proto.AdminDispatchRequest) (*proto.AdminDispatchResponse, error) {
+	st := h.wpp.DispatchStats()
+	return &proto.AdminDispatchResponse{
+		Workers:     int32(st.Workers),
+		Queued:      int32(st.Queued),
+		MaxQueued:   int32(st.MaxQueued),
+		Accounts:    int32(st.Accounts),
+		Processed:   st.Processed,
+		Dropped:     st.Dropped,
+		Panics:      st.Panics,
+		LastLatency: durationpb.New(st.LastLatency),
+		MaxLatency:  durationpb.New(st.MaxLatency),
+	}, nil
+}
+
+func (h *admin) CreateAPIKey(ctx context.Context, req *proto.AdminCreateAPIKeyRequest) (*proto.AdminCreateAPIKeyResponse, error) {
+	k, key, err := h.auth.CreateKey(ctx, req.Name, req.Accounts, req.Admin)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.AdminCreateAPIKeyResponse{ApiKey: toProtoAPIKey(k), Key: key}, nil
+}
+
+func (h *admin) RevokeAPIKey(ctx context.Context, req *proto.AdminRevokeAPIKeyRequest) (*proto.AdminRevokeAPIKeyResponse, error) {
+	err := h.auth.RevokeKey(ctx, req.Uuid)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.AdminRevokeAPIKeyResponse{}, nil
+}
+
+func (h *admin) ListAPIKeys(ctx context.Context, req *proto.AdminListAPIKeysRequest) (*proto.AdminListAPIKeysResponse, error) {
+	keys, err := h.auth.ListKeys(ctx)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	res := &proto.AdminListAPIKeysResponse{}
+	for _, k := range keys {
+		res.ApiKeys = append(res.ApiKeys, toProtoAPIKey(k))
+	}
+	return res, nil
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"qrpay-wpp/internal/api/model"
+	proto "qrpay-wpp/internal/api/proto/generated"
+	"qrpay-wpp/internal/api/service"
+	"qrpay-wpp/internal/common"
+	"qrpay-wpp/internal/errCode"
+)
+
+type Consent interface {
+	proto.ConsentServiceServer
+}
+
+type consent struct {
+	service service.Consent
+	proto.UnimplementedConsentServiceServer
+}
+
+func NewConsent(s service.Consent) Consent {
+	return &consent{service: s}
+}
+
+func toProtoConsentRecord(c *model.Consent) *proto.ConsentRecord {
+	return &proto.ConsentRecord{
+		Uuid:      c.UUID,
+		Phone:     c.Phone,
+		Action:    c.Action,
+		Source:    c.Source,
+		Evidence:  c.Evidence,
+		CreatedAt: timestamppb.New(c.CreatedAt),
+	}
+}
+
+func (h *consent) Get(ctx context.Context, req *proto.ConsentGetRequest) (*proto.ConsentGetResponse, error) {
+	sup, history, err := h.service.Get(ctx, req.AccountUUID, req.Phone)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	res := &proto.ConsentGetResponse{
+		Phone:      common.SanitizePhone(req.Phone),
+		Suppressed: sup != nil,
+	}
+	for _, c := range history {
+		res.History = append(res.History, toProtoConsentRecord(c))
+	}
+	return res, nil
+}
+
+func (h *consent) OptIn(ctx context.Context, req *proto.ConsentOptInRequest) (*proto.ConsentOptInResponse, error) {
+	c, err := h.service.OptIn(ctx, req.AccountUUID, req.Phone, req.Source, req.Evidence)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.ConsentOptInResponse{Record: toProtoConsentRecord(c)}, nil
+}
+
+func (h *consent) OptOut(ctx context.Context, req *proto.ConsentOptOutRequest) (*proto.ConsentOptOutResponse, error) {
+	c, err := h.service.OptOut(ctx, req.AccountUUID, req.Phone, req.Source, req.Evidence)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.ConsentOptOutResponse{Record: toProtoConsentRecord(c)}, nil
+}
+
+func (h *consent) ListSuppressed(ctx context.Context, req *proto.ConsentListSuppressedRequest) (*proto.ConsentListSuppressedResponse, error) {
+	sups, err := h.service.ListSuppressed(ctx, req.AccountUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	res := &proto.ConsentListSuppressedResponse{}
+	for _, sup := range sups {
+		res.Phones = append(res.Phones, &proto.SuppressedPhone{
+			Phone:     sup.Phone,
+			Reason:    sup.Reason,
+			CreatedAt: timestamppb.New(sup.CreatedAt),
+		})
+	}
+	return res, nil
+}
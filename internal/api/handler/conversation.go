@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"context"
+	errs "github.com/cristiancll/go-errors"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"qrpay-wpp/internal/api/model"
+	proto "qrpay-wpp/internal/api/proto/generated"
+	"qrpay-wpp/internal/api/service"
+	"qrpay-wpp/internal/errCode"
+)
+
+type Conversation interface {
+	proto.ConversationServiceServer
+}
+
+type conversation struct {
+	service service.Conversation
+	proto.UnimplementedConversationServiceServer
+}
+
+func NewConversation(s service.Conversation) Conversation {
+	return &conversation{service: s}
+}
+
+func toProtoConversation(conv *model.Conversation) *proto.Conversation {
+	return &proto.Conversation{
+		Uuid:        conv.UUID,
+		AccountUUID: conv.AccountUUID,
+		Phone:       conv.Phone,
+		Status:      conv.Status,
+		AgentUUID:   conv.AgentUUID,
+		CreatedAt:   timestamppb.New(conv.CreatedAt),
+		UpdatedAt:   timestamppb.New(conv.UpdatedAt),
+	}
+}
+
+func (h *conversation) List(ctx context.Context, req *proto.ConversationListRequest) (*proto.ConversationListResponse, error) {
+	convs, err := h.service.List(ctx, req.AccountUUID, req.Status)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	res := &proto.ConversationListResponse{}
+	for _, conv := range convs {
+		res.Conversations = append(res.Conversations, toProtoConversation(conv))
+	}
+	return res, nil
+}
+
+func (h *conversation) Handoff(ctx context.Context, req *proto.ConversationHandoffRequest) (*proto.ConversationHandoffResponse, error) {
+	conv, err := h.service.Handoff(ctx, req.AccountUUID, req.Phone)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.ConversationHandoffResponse{Conversation: toProtoConversation(conv)}, nil
+}
+
+func (h *conversation) Assign(ctx context.Context, req *proto.ConversationAssignRequest) (*proto.ConversationAssignResponse, error) {
+	conv, err := h.service.Assign(ctx, req.AccountUUID, req.ConversationUUID, req.AgentUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.ConversationAssignResponse{Conversation: toProtoConversation(conv)}, nil
+}
+
+func (h *conversation) Release(ctx context.Context, req *proto.ConversationReleaseRequest) (*proto.ConversationReleaseResponse, error) {
+	conv, err := h.service.Release(ctx, req.AccountUUID, req.ConversationUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.ConversationReleaseResponse{Conversation: toProtoConversation(conv)}, nil
+}
+
+func (h *conversation) Close(ctx context.Context, req *proto.ConversationCloseRequest) (*proto.ConversationCloseResponse, error) {
+	conv, err := h.service.Close(ctx, req.AccountUUID, req.ConversationUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.ConversationCloseResponse{Conversation: toProtoConversation(conv)}, nil
+}
+
+func (h *conversation) Stream(req *proto.ConversationStreamRequest, stream proto.ConversationService_StreamServer) error {
+	messages, unsubscribe := h.service.Subscribe(req.AccountUUID, req.AgentUUID)
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case msg := <-messages:
+			res := &proto.ConversationMessage{
+				ConversationUUID: msg.ConversationUUID,
+				AccountUUID:      msg.AccountUUID,
+				From:             msg.From,
+				Text:             msg.Text,
+				ReceivedAt:       timestamppb.New(msg.ReceivedAt),
+			}
+			err := stream.Send(res)
+			if err != nil {
+				return errs.New(err, errCode.Internal)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	errs "github.com/cristiancll/go-errors"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"qrpay-wpp/internal/api/service"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+// healthWatchInterval is how often Watch checks the service again.
+const healthWatchInterval = 5 * time.Second
+
+type Health interface {
+	healthpb.HealthServer
+}
+
+type health struct {
+	service service.Health
+	healthpb.UnimplementedHealthServer
+}
+
+func NewHealth(s service.Health) Health {
+	return &health{service: s}
+}
+
+// servingStatus checks the service, reporting unknown services as SERVICE_UNKNOWN.
+func (h *health) servingStatus(ctx context.Context, name string) (healthpb.HealthCheckResponse_ServingStatus, error) {
+	serving, err := h.service.Check(ctx, name)
+	if err != nil {
+		if e, ok := err.(*errs.Error); ok && e.Code == errCode.NotFound {
+			return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, nil
+		}
+		return healthpb.HealthCheckResponse_UNKNOWN, err
+	}
+	if serving {
+		return healthpb.HealthCheckResponse_SERVING, nil
+	}
+	return healthpb.HealthCheckResponse_NOT_SERVING, nil
+}
+
+func (h *health) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	st, err := h.servingStatus(ctx, req.Service)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	if st == healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: st}, nil
+}
+
+// Watch sends the status of the service, then every change of it, until the
+// client goes away.
+func (h *health) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(healthWatchInterval)
+	defer ticker.Stop()
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		st, err := h.servingStatus(ctx, req.Service)
+		if err != nil {
+			st = healthpb.HealthCheckResponse_UNKNOWN
+		}
+		if st != last {
+			err = stream.Send(&healthpb.HealthCheckResponse{Status: st})
+			if err != nil {
+				return errs.New(err, errCode.Internal)
+			}
+			last = st
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
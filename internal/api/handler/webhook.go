@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"qrpay-wpp/internal/api/model"
+	proto "qrpay-wpp/internal/api/proto/generated"
+	"qrpay-wpp/internal/api/service"
+	"qrpay-wpp/internal/errCode"
+)
+
+type Webhook interface {
+	proto.WebhookServiceServer
+}
+
+type webhook struct {
+	service service.Webhook
+	proto.UnimplementedWebhookServiceServer
+}
+
+func NewWebhook(s service.Webhook) Webhook {
+	return &webhook{service: s}
+}
+
+func toProtoWebhook(w *model.Webhook) *proto.Webhook {
+	return &proto.Webhook{
+		Uuid:        w.UUID,
+		AccountUUID: w.AccountUUID,
+		Url:         w.URL,
+		Secret:      w.Secret,
+		Active:      w.Active,
+		CreatedAt:   timestamppb.New(w.CreatedAt),
+	}
+}
+
+func (h *webhook) Create(ctx context.Context, req *proto.WebhookCreateRequest) (*proto.WebhookCreateResponse, error) {
+	w, err := h.service.Create(ctx, req.AccountUUID, req.Url, req.Secret)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.WebhookCreateResponse{Webhook: toProtoWebhook(w)}, nil
+}
+
+func (h *webhook) List(ctx context.Context, req *proto.WebhookListRequest) (*proto.WebhookListResponse, error) {
+	hooks, err := h.service.List(ctx, req.AccountUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	res := &proto.WebhookListResponse{}
+	for _, w := range hooks {
+		pw := toProtoWebhook(w)
+		pw.Secret = ""
+		res.Webhooks = append(res.Webhooks, pw)
+	}
+	return res, nil
+}
+
+func (h *webhook) Delete(ctx context.Context, req *proto.WebhookDeleteRequest) (*proto.WebhookDeleteResponse, error) {
+	err := h.service.Delete(ctx, req.AccountUUID, req.WebhookUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.WebhookDeleteResponse{}, nil
+}
+
+func (h *webhook) ListDeadLetters(ctx context.Context, req *proto.WebhookListDeadLettersRequest) (*proto.WebhookListDeadLettersResponse, error) {
+	letters, err := h.service.ListDeadLetters(ctx, req.AccountUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	res := &proto.WebhookListDeadLettersResponse{}
+	for _, l := range letters {
+		res.DeadLetters = append(res.DeadLetters, &proto.WebhookDeadLetter{
+			Uuid:        l.UUID,
+			WebhookUUID: l.WebhookUUID,
+			Event:       l.Event,
+			Payload:     l.Payload,
+			Attempts:    int32(l.Attempts),
+			LastError:   l.LastError,
+			CreatedAt:   timestamppb.New(l.CreatedAt),
+		})
+	}
+	return res, nil
+}
+
+func (h *webhook) Redeliver(ctx context.Context, req *proto.WebhookRedeliverRequest) (*proto.WebhookRedeliverResponse, error) {
+	err := h.service.Redeliver(ctx, req.AccountUUID, req.DeadLetterUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return &proto.WebhookRedeliverResponse{}, nil
+}
@@ -2,11 +2,15 @@ package handler
 
 import (
 	"context"
-	"errors"
 	errs "github.com/cristiancll/go-errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"qrpay-wpp/configs"
 	proto "qrpay-wpp/internal/api/proto/generated"
+	"qrpay-wpp/internal/api/qr"
 	"qrpay-wpp/internal/api/service"
 	"qrpay-wpp/internal/errCode"
 	"time"
@@ -15,50 +19,149 @@ import (
 type WhatsApp interface {
 	Connect(ctx context.Context, req *proto.WhatsAppConnectRequest) (*proto.WhatsAppConnectResponse, error)
 	Message(ctx context.Context, req *proto.WhatsAppMessageRequest) (*proto.WhatsAppMessageResponse, error)
-	QRCode(req *proto.WhatsAppQRRequest, stream proto.WhatsAppService_QRServer) error
+	Reply(ctx context.Context, req *proto.WhatsAppReplyRequest) (*proto.WhatsAppReplyResponse, error)
+	MessageStatus(ctx context.Context, req *proto.WhatsAppMessageStatusRequest) (*proto.WhatsAppMessageStatusResponse, error)
+	Warmup(ctx context.Context, req *proto.WhatsAppWarmupRequest) (*proto.WhatsAppWarmupResponse, error)
+	Status(ctx context.Context, req *proto.WhatsAppStatusRequest) (*proto.WhatsAppStatusResponse, error)
+	QR(req *proto.WhatsAppQRRequest, stream proto.WhatsAppService_QRServer) error
+	Events(req *proto.WhatsAppEventsRequest, stream proto.WhatsAppService_EventsServer) error
+	PairingLink(ctx context.Context, req *proto.WhatsAppPairingLinkRequest) (*proto.WhatsAppPairingLinkResponse, error)
 	proto.WhatsAppServiceServer
 }
 
 type whatsApp struct {
 	service service.WhatsApp
+	warmup  service.Warmup
+	pairing service.Pairing
 	proto.UnimplementedWhatsAppServiceServer
 }
 
-func NewWhatsApp(s service.WhatsApp) WhatsApp {
-	return &whatsApp{service: s}
+func NewWhatsApp(s service.WhatsApp, warmup service.Warmup, pairing service.Pairing) WhatsApp {
+	return &whatsApp{service: s, warmup: warmup, pairing: pairing}
 }
 
-func (h *whatsApp) Connect(ctx context.Context, req *proto.WhatsAppConnectRequest) (*proto.WhatsAppConnectResponse, error) {
-	if req.AccountUUID == "" {
-		return nil, errs.New(errors.New(""), errCode.InvalidArgument)
+// wrap wraps the errors of the service, except for statuses such as the
+// RetryAfter ones, which wrapping would strip of their code and details.
+func wrap(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
 	}
+	return errCode.Wrap(err)
+}
+
+func (h *whatsApp) Connect(ctx context.Context, req *proto.WhatsAppConnectRequest) (*proto.WhatsAppConnectResponse, error) {
 	err := h.service.Connect(ctx, req.AccountUUID)
 	if err != nil {
-		return nil, errs.Wrap(err, "")
+		return nil, wrap(err)
 	}
 	return &proto.WhatsAppConnectResponse{}, nil
 }
 
 func (h *whatsApp) Message(ctx context.Context, req *proto.WhatsAppMessageRequest) (*proto.WhatsAppMessageResponse, error) {
-	if req.AccountUUID == "" {
-		return nil, errs.New(errors.New(""), errCode.InvalidArgument)
+	msg, err := h.service.Message(ctx, req.AccountUUID, req.To, req.Text, req.Media)
+	if err != nil {
+		return nil, wrap(err)
+	}
+	return &proto.WhatsAppMessageResponse{MessageUUID: msg.UUID, Status: msg.Status}, nil
+}
+
+func (h *whatsApp) Reply(ctx context.Context, req *proto.WhatsAppReplyRequest) (*proto.WhatsAppReplyResponse, error) {
+	msg, err := h.service.Reply(ctx, req.AccountUUID, req.From, req.Text)
+	if err != nil {
+		return nil, wrap(err)
+	}
+	return &proto.WhatsAppReplyResponse{MessageUUID: msg.UUID, Status: msg.Status}, nil
+}
+
+func (h *whatsApp) MessageStatus(ctx context.Context, req *proto.WhatsAppMessageStatusRequest) (*proto.WhatsAppMessageStatusResponse, error) {
+	msg, err := h.service.MessageStatus(ctx, req.AccountUUID, req.MessageUUID)
+	if err != nil {
+		return nil, wrap(err)
 	}
-	if req.To == "" {
-		return nil, errs.New(errors.New(""), errCode.InvalidArgument)
+	res := &proto.WhatsAppMessageStatusResponse{
+		MessageUUID:       msg.UUID,
+		Status:            msg.Status,
+		Attempts:          int32(msg.Attempts),
+		LastError:         msg.LastError,
+		WhatsAppMessageID: msg.MessageID,
+		CreatedAt:         timestamppb.New(msg.CreatedAt),
 	}
-	if req.Text == "" || req.Media == nil {
-		return nil, errs.New(errors.New(""), errCode.InvalidArgument)
+	if msg.SentAt != nil {
+		res.SentAt = timestamppb.New(*msg.SentAt)
 	}
-	err := h.service.Message(ctx, req.AccountUUID, req.To, req.Text, req.Media)
+	return res, nil
+}
+
+func (h *whatsApp) Warmup(ctx context.Context, req *proto.WhatsAppWarmupRequest) (*proto.WhatsAppWarmupResponse, error) {
+	st, err := h.warmup.Status(ctx, req.AccountUUID)
 	if err != nil {
-		return nil, errs.Wrap(err, "")
+		return nil, errCode.Wrap(err)
+	}
+	res := &proto.WhatsAppWarmupResponse{
+		Policy:         st.Policy,
+		AgeDays:        int32(st.AgeDays),
+		Stage:          int32(st.Stage),
+		DailyAllowance: int32(st.DailyAllowance),
+		SentToday:      st.SentToday,
+		Remaining:      st.Remaining,
+		WarmedUp:       st.WarmedUp,
+	}
+	if !st.StageEndsAt.IsZero() {
+		res.StageEndsAt = timestamppb.New(st.StageEndsAt)
+	}
+	return res, nil
+}
+
+func (h *whatsApp) Status(ctx context.Context, req *proto.WhatsAppStatusRequest) (*proto.WhatsAppStatusResponse, error) {
+	st := h.service.Status(req.AccountUUID)
+	res := &proto.WhatsAppStatusResponse{
+		State:     string(st.State),
+		Phone:     st.Phone,
+		Attempts:  int32(st.Attempts),
+		LastError: st.LastError,
+	}
+	if !st.NextRetry.IsZero() {
+		res.NextRetryAt = timestamppb.New(st.NextRetry)
 	}
-	return &proto.WhatsAppMessageResponse{}, nil
+	return res, nil
 }
 
-func (h *whatsApp) QRCode(req *proto.WhatsAppQRRequest, stream proto.WhatsAppService_QRServer) error {
-	if req.AccountUUID == "" {
-		return errs.New(errors.New(""), errCode.InvalidArgument)
+// qrOptions merges the rendering options of the request with the configured defaults.
+func qrOptions(req *proto.WhatsAppQRRequest) qr.Options {
+	c := configs.Get().QR
+	opts := qr.Options{Size: c.Size, Margin: c.Margin, Level: c.ErrorCorrection}
+	if req.Size != 0 {
+		opts.Size = int(req.Size)
+	}
+	if req.Margin != 0 {
+		opts.Margin = int(req.Margin)
+	}
+	if req.ErrorCorrection != "" {
+		opts.Level = req.ErrorCorrection
+	}
+	return opts
+}
+
+// renderQR fills the response with the code rendered in the requested format.
+func renderQR(res *proto.WhatsAppQRResponse, format string, opts qr.Options) error {
+	var err error
+	switch format {
+	case "png":
+		res.Png, err = qr.PNG(res.Qr, opts)
+	case "svg":
+		res.Svg, err = qr.SVG(res.Qr, opts)
+	case "ascii":
+		res.Ascii, err = qr.ASCII(res.Qr, opts)
+	}
+	return err
+}
+
+func (h *whatsApp) QR(req *proto.WhatsAppQRRequest, stream proto.WhatsAppService_QRServer) error {
+	opts := qrOptions(req)
+	// Reject invalid options before waiting for a code
+	err := opts.Validate()
+	if err != nil {
+		return errs.New(err, errCode.InvalidArgument)
 	}
 	for {
 		if stream.Context().Err() == context.Canceled {
@@ -66,17 +169,26 @@ func (h *whatsApp) QRCode(req *proto.WhatsAppQRRequest, stream proto.WhatsAppSer
 		} else if stream.Context().Err() != nil {
 			break
 		}
-		qr, err := h.service.GetQRCode(req.AccountUUID)
+		code, err := h.service.GetQRCode(req.AccountUUID)
 		if err != nil {
 			s := status.Convert(err)
 			if s.Code() == codes.NotFound {
 				time.Sleep(500 * time.Millisecond)
 				continue
 			}
-			return errs.Wrap(err, "")
+			return wrap(err)
+		}
+		if code == "" {
+			// No code before the first one arrives, nor once paired
+			time.Sleep(500 * time.Millisecond)
+			continue
 		}
 		res := &proto.WhatsAppQRResponse{
-			Qr: qr,
+			Qr: code,
+		}
+		err = renderQR(res, req.Format, opts)
+		if err != nil {
+			return errs.New(err, errCode.Internal)
 		}
 		err = stream.Send(res)
 		if err != nil {
@@ -86,3 +198,46 @@ func (h *whatsApp) QRCode(req *proto.WhatsAppQRRequest, stream proto.WhatsAppSer
 	}
 	return nil
 }
+
+func (h *whatsApp) Events(req *proto.WhatsAppEventsRequest, stream proto.WhatsAppService_EventsServer) error {
+	messages, unsubscribe := h.service.Events()
+	defer unsubscribe()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			if msg.AccountUUID != req.AccountUUID {
+				continue
+			}
+			res := &proto.WhatsAppEvent{
+				Type:        msg.Type,
+				AccountUUID: msg.AccountUUID,
+				Node:        msg.Node,
+				At:          timestamppb.New(msg.At),
+				Truncated:   msg.Truncated,
+			}
+			if len(msg.Data) > 0 {
+				data := &structpb.Struct{}
+				if protojson.Unmarshal(msg.Data, data) == nil {
+					res.Data = data
+				}
+			}
+			err := stream.Send(res)
+			if err != nil {
+				return errs.New(err, errCode.Internal)
+			}
+		}
+	}
+}
+
+func (h *whatsApp) PairingLink(ctx context.Context, req *proto.WhatsAppPairingLinkRequest) (*proto.WhatsAppPairingLinkResponse, error) {
+	url, expiresAt, err := h.pairing.Link(req.AccountUUID)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return &proto.WhatsAppPairingLinkResponse{Url: url, ExpiresAt: timestamppb.New(expiresAt)}, nil
+}
@@ -3,9 +3,19 @@ package server
 import "qrpay-wpp/internal/api/handler"
 
 type handlers struct {
-	wpp handler.WhatsApp
+	wpp     handler.WhatsApp
+	conv    handler.Conversation
+	consent handler.Consent
+	webhook handler.Webhook
+	admin   handler.Admin
+	health  handler.Health
 }
 
 func (s *Server) createHandlers() {
-	s.handlers.wpp = handler.NewWhatsApp(s.services.wpp)
+	s.handlers.wpp = handler.NewWhatsApp(s.services.wpp, s.services.warmup, s.services.pairing)
+	s.handlers.conv = handler.NewConversation(s.services.conv)
+	s.handlers.consent = handler.NewConsent(s.services.consent)
+	s.handlers.webhook = handler.NewWebhook(s.services.webhook)
+	s.handlers.admin = handler.NewAdmin(s.services.recon, s.services.wpp, s.services.auth)
+	s.handlers.health = handler.NewHealth(s.services.health)
 }
@@ -0,0 +1,197 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	errs "github.com/cristiancll/go-errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"strings"
+)
+
+// apiKeyHeader carries an API key for clients that cannot set the authorization header.
+const apiKeyHeader = "x-api-key"
+
+// ProxyHeader marks requests sent on behalf of a caller by the HTTP gateway.
+const ProxyHeader = "x-proxied-by"
+
+// Authenticator resolves the caller from the credentials of a request.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*model.Principal, error)
+}
+
+// PeerAuthenticator resolves the caller from its verified client certificate,
+// returning nil when the certificate identifies no caller.
+type PeerAuthenticator interface {
+	AuthenticatePeer(cert *x509.Certificate) *model.Principal
+}
+
+type principalKey struct{}
+
+// PrincipalFrom returns the caller authenticated by the Auth interceptor.
+func PrincipalFrom(ctx context.Context) *model.Principal {
+	p, _ := ctx.Value(principalKey{}).(*model.Principal)
+	return p
+}
+
+// Auth authenticates every RPC and makes sure the caller may act on the account
+// of the request. Admin services are restricted to admin callers, public ones
+// are open to anyone.
+type Auth struct {
+	authenticator Authenticator
+	peers         PeerAuthenticator
+	admin         map[string]bool
+	public        map[string]bool
+}
+
+// NewAuth restricts the given services, such as "proto.AdminService", to admin
+// callers. Callers without credentials are identified by their client certificate
+// when peers is not nil.
+func NewAuth(authenticator Authenticator, peers PeerAuthenticator, adminServices ...string) *Auth {
+	a := &Auth{authenticator: authenticator, peers: peers, admin: map[string]bool{}, public: map[string]bool{}}
+	for _, s := range adminServices {
+		a.admin[s] = true
+	}
+	return a
+}
+
+// Public opens the given services, such as "grpc.health.v1.Health", to callers
+// without credentials.
+func (a *Auth) Public(services ...string) *Auth {
+	for _, s := range services {
+		a.public[s] = true
+	}
+	return a
+}
+
+func serviceOf(method string) string {
+	service, _, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return service
+}
+
+// bearerToken returns the API key or JWT sent by the caller.
+func bearerToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, v := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(v, " ")
+		if ok && strings.EqualFold(scheme, "bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	if v := md.Get(apiKeyHeader); len(v) > 0 {
+		return strings.TrimSpace(v[0])
+	}
+	return ""
+}
+
+// proxied reports whether the request was relayed by the gateway or another node.
+// Their client certificate is the one of the node, not of the caller.
+func proxied(ctx context.Context) bool {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return len(md.Get(ProxyHeader)) > 0 || len(md.Get(forwardedHeader)) > 0
+}
+
+// peerCertificate returns the verified client certificate of the caller.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return info.State.VerifiedChains[0][0]
+}
+
+// authenticate resolves the caller and checks its access to the service of the method.
+func (a *Auth) authenticate(ctx context.Context, method string) (context.Context, error) {
+	token := bearerToken(ctx)
+	var p *model.Principal
+	if token == "" && a.peers != nil && !proxied(ctx) {
+		if cert := peerCertificate(ctx); cert != nil {
+			p = a.peers.AuthenticatePeer(cert)
+		}
+	}
+	if p == nil {
+		var err error
+		p, err = a.authenticator.Authenticate(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if a.admin[serviceOf(method)] && !p.Admin {
+		return nil, errs.New(errors.New("admin access required"), errCode.AccessDenied)
+	}
+	if !p.Permits(method) {
+		return nil, errs.New(errors.New("method not permitted"), errCode.AccessDenied)
+	}
+	return context.WithValue(ctx, principalKey{}, p), nil
+}
+
+// authorize checks that the caller may act on the account of the request.
+// Requests without an account are left to the validation.
+func authorize(ctx context.Context, req any) error {
+	scoped, ok := req.(accountScoped)
+	if !ok || scoped.GetAccountUUID() == "" {
+		return nil
+	}
+	p := PrincipalFrom(ctx)
+	if p == nil || !p.Allows(scoped.GetAccountUUID()) {
+		return errs.New(errors.New("access to the account is denied"), errCode.AccessDenied)
+	}
+	return nil
+}
+
+func (a *Auth) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if a.public[serviceOf(info.FullMethod)] {
+			return handler(ctx, req)
+		}
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		err = authorize(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// authorizedStream carries the caller and authorizes every request received by the handler.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+	return authorize(s.ctx, m)
+}
+
+func (a *Auth) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a.public[serviceOf(info.FullMethod)] {
+			return handler(srv, ss)
+		}
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authorizedStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -0,0 +1,98 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	errs "github.com/cristiancll/go-errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+	"qrpay-wpp/internal/errCode"
+)
+
+// errorDomain is the domain reported in the ErrorInfo details.
+const errorDomain = "qrpay-wpp"
+
+// Errors translates the errs errors returned by handlers into gRPC statuses.
+// Internal errors are logged with their stack and reach clients without their
+// message, which may hold queries or driver errors.
+type Errors struct{}
+
+func NewErrors() *Errors {
+	return &Errors{}
+}
+
+func (e *Errors) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		if err != nil {
+			return nil, e.convert(ctx, info.FullMethod, err)
+		}
+		return res, nil
+	}
+}
+
+func (e *Errors) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			return e.convert(ss.Context(), info.FullMethod, err)
+		}
+		return nil
+	}
+}
+
+// convert translates the error of the RPC. Errors wrapped with errCode.Wrap
+// carry the code, message and metadata of the error they wrap.
+func (e *Errors) convert(ctx context.Context, method string, err error) error {
+	// Statuses built by the handlers or the forwarder already carry their code and details
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	// Errors of a call the client gave up on are the outcome of that, whatever they wrap
+	if errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
+		return status.Error(codes.Canceled, context.Canceled.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+	}
+
+	var code errs.Code
+	var message string
+	var metadata []any
+	if er, ok := err.(*errs.Error); ok {
+		code, message, metadata = er.Code, er.Message, er.Metadata
+	}
+	grpcCode := errCode.ToGRPCCode(code)
+	if grpcCode == codes.Internal || grpcCode == codes.Unknown {
+		fmt.Printf("Internal error on %s: %v\n", method, err)
+		code, grpcCode, message = errCode.Internal, codes.Internal, ""
+	}
+	if message == "" {
+		message = errCode.Describe(code)
+	}
+
+	st := status.New(grpcCode, message)
+	details := []protoiface.MessageV1{&errdetails.ErrorInfo{
+		Reason:   errCode.Reason(code),
+		Domain:   errorDomain,
+		Metadata: map[string]string{"method": method},
+	}}
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, m := range metadata {
+		if v, ok := m.(errCode.Violation); ok {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Description})
+		}
+	}
+	if len(violations) > 0 {
+		details = append(details, &errdetails.BadRequest{FieldViolations: violations})
+	}
+	detailed, er := st.WithDetails(details...)
+	if er != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
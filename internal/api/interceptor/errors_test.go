@@ -0,0 +1,48 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	errs "github.com/cristiancll/go-errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"qrpay-wpp/internal/errCode"
+	"testing"
+)
+
+func TestConvertKeepsWrappedCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{
+			name:    "system status",
+			err:     errCode.Wrap(errCode.Wrap(status.Error(codes.NotFound, "connection not found"))),
+			code:    codes.NotFound,
+			message: "connection not found",
+		},
+		{
+			name:    "service error",
+			err:     errCode.Wrap(errCode.Wrap(errs.New(errors.New("webhook not found"), errCode.NotFound))),
+			code:    codes.NotFound,
+			message: "webhook not found",
+		},
+		{
+			name:    "internal error",
+			err:     errCode.Wrap(errs.New(errors.New("connection refused"), errCode.Internal)),
+			code:    codes.Internal,
+			message: "internal",
+		},
+	}
+	e := NewErrors()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(e.convert(context.Background(), "/proto.WhatsAppService/Connect", tt.err))
+			if st.Code() != tt.code || st.Message() != tt.message {
+				t.Errorf("got %v: %s, want %v: %s", st.Code(), st.Message(), tt.code, tt.message)
+			}
+		})
+	}
+}
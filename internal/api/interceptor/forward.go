@@ -0,0 +1,230 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"io"
+	"strings"
+	"sync"
+)
+
+// forwardedHeader marks requests forwarded by another node, which are always
+// handled locally so that a stale lease can never bounce a request around.
+const forwardedHeader = "x-forwarded-by"
+
+// Router resolves the node owning an account.
+type Router interface {
+	NodeID() string
+	Route(ctx context.Context, accountUUID string) (string, error)
+}
+
+type accountScoped interface {
+	GetAccountUUID() string
+}
+
+// Forwarder sends account-scoped RPCs to the node owning the account, so that
+// clients can reach any node behind a load balancer.
+type Forwarder struct {
+	router  Router
+	creds   credentials.TransportCredentials
+	methods map[string]bool
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// NewForwarder forwards the given full method names, such as "/proto.WhatsAppService/Message",
+// reaching the other nodes with creds, or in plaintext when creds is nil.
+func NewForwarder(router Router, creds credentials.TransportCredentials, methods ...string) *Forwarder {
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	f := &Forwarder{
+		router:  router,
+		creds:   creds,
+		methods: map[string]bool{},
+		conns:   map[string]*grpc.ClientConn{},
+	}
+	for _, m := range methods {
+		f.methods[m] = true
+	}
+	return f
+}
+
+func (f *Forwarder) conn(address string) (*grpc.ClientConn, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if conn, ok := f.conns[address]; ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(f.creds))
+	if err != nil {
+		return nil, err
+	}
+	f.conns[address] = conn
+	return conn, nil
+}
+
+// target returns the address to forward the request to, empty to handle it locally.
+func (f *Forwarder) target(ctx context.Context, method string, req any) (string, error) {
+	if !f.methods[method] {
+		return "", nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md.Get(forwardedHeader)) > 0 {
+		return "", nil
+	}
+	scoped, ok := req.(accountScoped)
+	if !ok || scoped.GetAccountUUID() == "" {
+		return "", nil
+	}
+	return f.router.Route(ctx, scoped.GetAccountUUID())
+}
+
+// outgoing carries the caller's metadata over to the owning node.
+func (f *Forwarder) outgoing(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	md.Set(forwardedHeader, f.router.NodeID())
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+func methodDescriptor(method string) (protoreflect.MethodDescriptor, error) {
+	name := strings.ReplaceAll(strings.TrimPrefix(method, "/"), "/", ".")
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, err
+	}
+	md, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a method", name)
+	}
+	return md, nil
+}
+
+func newMessage(name protoreflect.FullName) (proto.Message, error) {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(name)
+	if err != nil {
+		return nil, err
+	}
+	return mt.New().Interface(), nil
+}
+
+func (f *Forwarder) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		address, err := f.target(ctx, info.FullMethod, req)
+		if err != nil {
+			return nil, err
+		}
+		if address == "" {
+			return handler(ctx, req)
+		}
+		conn, err := f.conn(address)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "unable to reach owner node: %v", err)
+		}
+		md, err := methodDescriptor(info.FullMethod)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		res, err := newMessage(md.Output().FullName())
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		err = conn.Invoke(f.outgoing(ctx), info.FullMethod, req, res)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+// bufferedStream replays the request already received by the interceptor to the handler.
+type bufferedStream struct {
+	grpc.ServerStream
+	req      proto.Message
+	received bool
+}
+
+func (s *bufferedStream) RecvMsg(m any) error {
+	if s.received {
+		return s.ServerStream.RecvMsg(m)
+	}
+	s.received = true
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+// Stream forwards server streaming RPCs, whose single request carries the account.
+func (f *Forwarder) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !f.methods[info.FullMethod] || info.IsClientStream {
+			return handler(srv, ss)
+		}
+		md, err := methodDescriptor(info.FullMethod)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		req, err := newMessage(md.Input().FullName())
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		err = ss.RecvMsg(req)
+		if err != nil {
+			return err
+		}
+		stream := &bufferedStream{ServerStream: ss, req: req}
+		address, err := f.target(ss.Context(), info.FullMethod, req)
+		if err != nil {
+			return err
+		}
+		if address == "" {
+			return handler(srv, stream)
+		}
+
+		conn, err := f.conn(address)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "unable to reach owner node: %v", err)
+		}
+		ctx, cancel := context.WithCancel(f.outgoing(ss.Context()))
+		defer cancel()
+		client, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		err = client.SendMsg(req)
+		if err != nil {
+			return err
+		}
+		err = client.CloseSend()
+		if err != nil {
+			return err
+		}
+		for {
+			res, err := newMessage(md.Output().FullName())
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			err = client.RecvMsg(res)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			err = ss.SendMsg(res)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"net/http"
+	"net/url"
+	pb "qrpay-wpp/internal/api/proto/generated"
+	"qrpay-wpp/internal/errCode"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// phonePattern matches international numbers as whatsmeow expects them in a
+// JID: country code and number, digits only. The leading "+" of the E.164 form
+// is accepted too, common.SanitizePhone strips it.
+var phonePattern = regexp.MustCompile(`^\+?[1-9][0-9]{7,14}$`)
+
+// Validator enforces the rules declared on the request messages through the
+// options of validate.proto, rejecting invalid requests with field violations.
+type Validator struct{}
+
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+func (v *Validator) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if m, ok := req.(proto.Message); ok {
+			err := validate(m)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// validatingStream validates every request received by the handler.
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		return validate(msg)
+	}
+	return nil
+}
+
+func (v *Validator) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ServerStream: ss})
+	}
+}
+
+func validate(m proto.Message) error {
+	msg := m.ProtoReflect()
+	desc := msg.Descriptor()
+	var violations []any
+
+	rules, _ := proto.GetExtension(desc.Options(), pb.E_MessageRules).(*pb.MessageRules)
+	if rules != nil && len(rules.RequireAny) > 0 {
+		set := false
+		for _, name := range rules.RequireAny {
+			fd := desc.Fields().ByName(protoreflect.Name(name))
+			if fd != nil && msg.Has(fd) {
+				set = true
+			}
+		}
+		if !set {
+			for _, name := range rules.RequireAny {
+				violations = append(violations, errCode.Violation{
+					Field:       name,
+					Description: "one of " + strings.Join(rules.RequireAny, ", ") + " is required",
+				})
+			}
+		}
+	}
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if fd.IsList() || fd.IsMap() {
+			continue
+		}
+		fieldRules, _ := proto.GetExtension(fd.Options(), pb.E_Rules).(*pb.FieldRules)
+		if fieldRules == nil {
+			continue
+		}
+		description := validateField(fieldRules, fd, msg)
+		if description != "" {
+			violations = append(violations, errCode.Violation{Field: string(fd.Name()), Description: description})
+		}
+	}
+
+	if len(violations) > 0 {
+		return errs.New(errors.New("invalid request"), errCode.InvalidArgument, violations...)
+	}
+	return nil
+}
+
+// validateField returns the description of the first rule the field breaks, empty when valid.
+func validateField(rules *pb.FieldRules, fd protoreflect.FieldDescriptor, msg protoreflect.Message) string {
+	if !msg.Has(fd) {
+		if rules.Required {
+			return "is required"
+		}
+		return ""
+	}
+	value := msg.Get(fd)
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		s := value.String()
+		if rules.MaxLength > 0 && utf8.RuneCountInString(s) > int(rules.MaxLength) {
+			return fmt.Sprintf("must be at most %d characters", rules.MaxLength)
+		}
+		if rules.Uuid {
+			_, err := uuid.Parse(s)
+			if err != nil {
+				return "must be a UUID"
+			}
+		}
+		if rules.Phone && !phonePattern.MatchString(s) {
+			return "must be an international phone number, digits only with an optional leading +"
+		}
+		if rules.Url {
+			u, err := url.Parse(s)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return "must be an http or https URL"
+			}
+		}
+		if len(rules.In) > 0 && !contains(rules.In, s) {
+			return "must be one of " + strings.Join(rules.In, ", ")
+		}
+	case protoreflect.BytesKind:
+		b := value.Bytes()
+		if rules.MaxBytes > 0 && int64(len(b)) > rules.MaxBytes {
+			return fmt.Sprintf("must be at most %d bytes", rules.MaxBytes)
+		}
+		if len(rules.MimeTypes) > 0 {
+			mimeType, _, _ := strings.Cut(http.DetectContentType(b), ";")
+			if !contains(rules.MimeTypes, mimeType) {
+				return "must be one of " + strings.Join(rules.MimeTypes, ", ") + ", not " + mimeType
+			}
+		}
+	}
+	return ""
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
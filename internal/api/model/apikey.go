@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// APIKey authenticates a caller with a static key, of which only the SHA-256
+// hash is stored. Prefix holds the first characters of the key to tell keys apart.
+type APIKey struct {
+	ID         int64      `db:"id"`
+	UUID       string     `db:"uuid"`
+	Name       string     `db:"name"`
+	Prefix     string     `db:"prefix"`
+	Hash       string     `db:"hash"`
+	Accounts   []string   `db:"accounts"`
+	Admin      bool       `db:"admin"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
+
+// AllAccounts in the accounts of a caller grants access to every account.
+const AllAccounts = "*"
+
+// Principal is the authenticated caller of an RPC. Methods restricts the RPCs
+// the caller may call, any when empty.
+type Principal struct {
+	Subject  string
+	Method   string
+	Accounts []string
+	Admin    bool
+	Methods  []string
+}
+
+// Permits reports whether the caller may call the RPC with the full method name.
+func (p *Principal) Permits(method string) bool {
+	if len(p.Methods) == 0 {
+		return true
+	}
+	for _, m := range p.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows reports whether the caller may act on the account.
+func (p *Principal) Allows(accountUUID string) bool {
+	if p.Admin {
+		return true
+	}
+	for _, a := range p.Accounts {
+		if a == accountUUID || a == AllAccounts {
+			return true
+		}
+	}
+	return false
+}
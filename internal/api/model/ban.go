@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Ban is an entry of the ban history of an account, lifted once the account connects again.
+type Ban struct {
+	ID          int64      `db:"id"`
+	UUID        string     `db:"uuid"`
+	AccountUUID string     `db:"account_uuid"`
+	Phone       string     `db:"phone"`
+	Code        int        `db:"code"`
+	Reason      string     `db:"reason"`
+	ExpiresAt   *time.Time `db:"expires_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+	LiftedAt    *time.Time `db:"lifted_at"`
+}
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+const (
+	ConsentOptIn  = "opt_in"
+	ConsentOptOut = "opt_out"
+)
+
+// Consent is an entry of the consent ledger. Entries are never updated.
+type Consent struct {
+	ID          int64     `db:"id"`
+	UUID        string    `db:"uuid"`
+	AccountUUID string    `db:"account_uuid"`
+	Phone       string    `db:"phone"`
+	Action      string    `db:"action"`
+	Source      string    `db:"source"`
+	Evidence    string    `db:"evidence"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type Suppression struct {
+	ID          int64     `db:"id"`
+	AccountUUID string    `db:"account_uuid"`
+	Phone       string    `db:"phone"`
+	Reason      string    `db:"reason"`
+	CreatedAt   time.Time `db:"created_at"`
+}
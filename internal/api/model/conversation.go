@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+const (
+	ConversationBot      = "bot"
+	ConversationWaiting  = "waiting"
+	ConversationAssigned = "assigned"
+	ConversationClosed   = "closed"
+)
+
+type Conversation struct {
+	ID          int64     `db:"id"`
+	UUID        string    `db:"uuid"`
+	AccountUUID string    `db:"account_uuid"`
+	Phone       string    `db:"phone"`
+	Status      string    `db:"status"`
+	AgentUUID   string    `db:"agent_uuid"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
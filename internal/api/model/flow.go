@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type FlowSession struct {
+	ID          int64             `db:"id"`
+	UUID        string            `db:"uuid"`
+	AccountUUID string            `db:"account_uuid"`
+	Phone       string            `db:"phone"`
+	Flow        string            `db:"flow"`
+	Step        string            `db:"step"`
+	Data        map[string]string `db:"data"`
+	ExpiresAt   time.Time         `db:"expires_at"`
+	CreatedAt   time.Time         `db:"created_at"`
+	UpdatedAt   time.Time         `db:"updated_at"`
+}
@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+// AccountLease records which node owns the session of an account. Ownership
+// itself is enforced by an advisory lock, the lease only makes it visible.
+type AccountLease struct {
+	AccountUUID string    `db:"account_uuid"`
+	NodeID      string    `db:"node_id"`
+	AcquiredAt  time.Time `db:"acquired_at"`
+	RenewedAt   time.Time `db:"renewed_at"`
+}
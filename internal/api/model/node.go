@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// Node is a running replica, reachable by the others at its internal address.
+type Node struct {
+	ID          string    `db:"id"`
+	Address     string    `db:"address"`
+	StartedAt   time.Time `db:"started_at"`
+	HeartbeatAt time.Time `db:"heartbeat_at"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+const (
+	OutboxQueued  = "queued"
+	OutboxSending = "sending"
+	OutboxSent    = "sent"
+	OutboxFailed  = "failed"
+)
+
+type OutboundMessage struct {
+	ID            int64      `db:"id"`
+	UUID          string     `db:"uuid"`
+	AccountUUID   string     `db:"account_uuid"`
+	Recipient     string     `db:"recipient"`
+	Text          string     `db:"text"`
+	Media         []byte     `db:"media"`
+	Status        string     `db:"status"`
+	Attempts      int        `db:"attempts"`
+	LastError     string     `db:"last_error"`
+	MessageID     string     `db:"message_id"`
+	NextAttemptAt time.Time  `db:"next_attempt_at"`
+	SentAt        *time.Time `db:"sent_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
+}
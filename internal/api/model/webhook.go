@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+type Webhook struct {
+	ID          int64     `db:"id"`
+	UUID        string    `db:"uuid"`
+	AccountUUID string    `db:"account_uuid"`
+	URL         string    `db:"url"`
+	Secret      string    `db:"secret"`
+	Active      bool      `db:"active"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+type WebhookDelivery struct {
+	ID            int64     `db:"id"`
+	UUID          string    `db:"uuid"`
+	WebhookUUID   string    `db:"webhook_uuid"`
+	AccountUUID   string    `db:"account_uuid"`
+	Event         string    `db:"event"`
+	Payload       []byte    `db:"payload"`
+	Attempts      int       `db:"attempts"`
+	LastError     string    `db:"last_error"`
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+type WebhookDeadLetter struct {
+	ID          int64     `db:"id"`
+	UUID        string    `db:"uuid"`
+	WebhookUUID string    `db:"webhook_uuid"`
+	AccountUUID string    `db:"account_uuid"`
+	Event       string    `db:"event"`
+	Payload     []byte    `db:"payload"`
+	Attempts    int       `db:"attempts"`
+	LastError   string    `db:"last_error"`
+	CreatedAt   time.Time `db:"created_at"`
+}
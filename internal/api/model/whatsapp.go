@@ -3,13 +3,16 @@ package model
 import "time"
 
 type WhatsApp struct {
-	ID          int64     `db:"id"`
-	UUID        string    `db:"uuid"`
-	AccountUUID string    `db:"account_uuid"`
-	Phone       string    `db:"phone"`
-	Connected   bool      `db:"connected"`
-	Active      bool      `db:"active"`
-	Banned      bool      `db:"banned"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID           int64      `db:"id"`
+	UUID         string     `db:"uuid"`
+	AccountUUID  string     `db:"account_uuid"`
+	Phone        string     `db:"phone"`
+	Connected    bool       `db:"connected"`
+	Active       bool       `db:"active"`
+	Banned       bool       `db:"banned"`
+	BanCode      int        `db:"ban_code"`
+	BanReason    string     `db:"ban_reason"`
+	BanExpiresAt *time.Time `db:"ban_expires_at"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at"`
 }
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.22.2
+// source: consent.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ConsentServiceClient is the client API for ConsentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConsentServiceClient interface {
+	Get(ctx context.Context, in *ConsentGetRequest, opts ...grpc.CallOption) (*ConsentGetResponse, error)
+	OptIn(ctx context.Context, in *ConsentOptInRequest, opts ...grpc.CallOption) (*ConsentOptInResponse, error)
+	OptOut(ctx context.Context, in *ConsentOptOutRequest, opts ...grpc.CallOption) (*ConsentOptOutResponse, error)
+	ListSuppressed(ctx context.Context, in *ConsentListSuppressedRequest, opts ...grpc.CallOption) (*ConsentListSuppressedResponse, error)
+}
+
+type consentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConsentServiceClient(cc grpc.ClientConnInterface) ConsentServiceClient {
+	return &consentServiceClient{cc}
+}
+
+func (c *consentServiceClient) Get(ctx context.Context, in *ConsentGetRequest, opts ...grpc.CallOption) (*ConsentGetResponse, error) {
+	out := new(ConsentGetResponse)
+	err := c.cc.Invoke(ctx, "/proto.ConsentService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) OptIn(ctx context.Context, in *ConsentOptInRequest, opts ...grpc.CallOption) (*ConsentOptInResponse, error) {
+	out := new(ConsentOptInResponse)
+	err := c.cc.Invoke(ctx, "/proto.ConsentService/OptIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) OptOut(ctx context.Context, in *ConsentOptOutRequest, opts ...grpc.CallOption) (*ConsentOptOutResponse, error) {
+	out := new(ConsentOptOutResponse)
+	err := c.cc.Invoke(ctx, "/proto.ConsentService/OptOut", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *consentServiceClient) ListSuppressed(ctx context.Context, in *ConsentListSuppressedRequest, opts ...grpc.CallOption) (*ConsentListSuppressedResponse, error) {
+	out := new(ConsentListSuppressedResponse)
+	err := c.cc.Invoke(ctx, "/proto.ConsentService/ListSuppressed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ConsentServiceServer is the server API for ConsentService service.
+// All implementations must embed UnimplementedConsentServiceServer
+// for forward compatibility
+type ConsentServiceServer interface {
+	Get(context.Context, *ConsentGetRequest) (*ConsentGetResponse, error)
+	OptIn(context.Context, *ConsentOptInRequest) (*ConsentOptInResponse, error)
+	OptOut(context.Context, *ConsentOptOutRequest) (*ConsentOptOutResponse, error)
+	ListSuppressed(context.Context, *ConsentListSuppressedRequest) (*ConsentListSuppressedResponse, error)
+	mustEmbedUnimplementedConsentServiceServer()
+}
+
+// UnimplementedConsentServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedConsentServiceServer struct {
+}
+
+func (UnimplementedConsentServiceServer) Get(context.Context, *ConsentGetRequest) (*ConsentGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedConsentServiceServer) OptIn(context.Context, *ConsentOptInRequest) (*ConsentOptInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OptIn not implemented")
+}
+func (UnimplementedConsentServiceServer) OptOut(context.Context, *ConsentOptOutRequest) (*ConsentOptOutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OptOut not implemented")
+}
+func (UnimplementedConsentServiceServer) ListSuppressed(context.Context, *ConsentListSuppressedRequest) (*ConsentListSuppressedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSuppressed not implemented")
+}
+func (UnimplementedConsentServiceServer) mustEmbedUnimplementedConsentServiceServer() {}
+
+// UnsafeConsentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConsentServiceServer will
+// result in compilation errors.
+type UnsafeConsentServiceServer interface {
+	mustEmbedUnimplementedConsentServiceServer()
+}
+
+func RegisterConsentServiceServer(s grpc.ServiceRegistrar, srv ConsentServiceServer) {
+	s.RegisterService(&ConsentService_ServiceDesc, srv)
+}
+
+func _ConsentService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsentGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ConsentService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).Get(ctx, req.(*ConsentGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_OptIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsentOptInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).OptIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ConsentService/OptIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).OptIn(ctx, req.(*ConsentOptInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_OptOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsentOptOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).OptOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ConsentService/OptOut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).OptOut(ctx, req.(*ConsentOptOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConsentService_ListSuppressed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsentListSuppressedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConsentServiceServer).ListSuppressed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ConsentService/ListSuppressed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConsentServiceServer).ListSuppressed(ctx, req.(*ConsentListSuppressedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ConsentService_ServiceDesc is the grpc.ServiceDesc for ConsentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConsentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.ConsentService",
+	HandlerType: (*ConsentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _ConsentService_Get_Handler,
+		},
+		{
+			MethodName: "OptIn",
+			Handler:    _ConsentService_OptIn_Handler,
+		},
+		{
+			MethodName: "OptOut",
+			Handler:    _ConsentService_OptOut_Handler,
+		},
+		{
+			MethodName: "ListSuppressed",
+			Handler:    _ConsentService_ListSuppressed_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "consent.proto",
+}
@@ -0,0 +1,313 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.22.2
+// source: conversation.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ConversationServiceClient is the client API for ConversationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConversationServiceClient interface {
+	List(ctx context.Context, in *ConversationListRequest, opts ...grpc.CallOption) (*ConversationListResponse, error)
+	Handoff(ctx context.Context, in *ConversationHandoffRequest, opts ...grpc.CallOption) (*ConversationHandoffResponse, error)
+	Assign(ctx context.Context, in *ConversationAssignRequest, opts ...grpc.CallOption) (*ConversationAssignResponse, error)
+	Release(ctx context.Context, in *ConversationReleaseRequest, opts ...grpc.CallOption) (*ConversationReleaseResponse, error)
+	Close(ctx context.Context, in *ConversationCloseRequest, opts ...grpc.CallOption) (*ConversationCloseResponse, error)
+	Stream(ctx context.Context, in *ConversationStreamRequest, opts ...grpc.CallOption) (ConversationService_StreamClient, error)
+}
+
+type conversationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConversationServiceClient(cc grpc.ClientConnInterface) ConversationServiceClient {
+	return &conversationServiceClient{cc}
+}
+
+func (c *conversationServiceClient) List(ctx context.Context, in *ConversationListRequest, opts ...grpc.CallOption) (*ConversationListResponse, error) {
+	out := new(ConversationListResponse)
+	err := c.cc.Invoke(ctx, "/proto.ConversationService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) Handoff(ctx context.Context, in *ConversationHandoffRequest, opts ...grpc.CallOption) (*ConversationHandoffResponse, error) {
+	out := new(ConversationHandoffResponse)
+	err := c.cc.Invoke(ctx, "/proto.ConversationService/Handoff", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) Assign(ctx context.Context, in *ConversationAssignRequest, opts ...grpc.CallOption) (*ConversationAssignResponse, error) {
+	out := new(ConversationAssignResponse)
+	err := c.cc.Invoke(ctx, "/proto.ConversationService/Assign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) Release(ctx context.Context, in *ConversationReleaseRequest, opts ...grpc.CallOption) (*ConversationReleaseResponse, error) {
+	out := new(ConversationReleaseResponse)
+	err := c.cc.Invoke(ctx, "/proto.ConversationService/Release", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) Close(ctx context.Context, in *ConversationCloseRequest, opts ...grpc.CallOption) (*ConversationCloseResponse, error) {
+	out := new(ConversationCloseResponse)
+	err := c.cc.Invoke(ctx, "/proto.ConversationService/Close", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationServiceClient) Stream(ctx context.Context, in *ConversationStreamRequest, opts ...grpc.CallOption) (ConversationService_StreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ConversationService_ServiceDesc.Streams[0], "/proto.ConversationService/Stream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &conversationServiceStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ConversationService_StreamClient interface {
+	Recv() (*ConversationMessage, error)
+	grpc.ClientStream
+}
+
+type conversationServiceStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *conversationServiceStreamClient) Recv() (*ConversationMessage, error) {
+	m := new(ConversationMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ConversationServiceServer is the server API for ConversationService service.
+// All implementations must embed UnimplementedConversationServiceServer
+// for forward compatibility
+type ConversationServiceServer interface {
+	List(context.Context, *ConversationListRequest) (*ConversationListResponse, error)
+	Handoff(context.Context, *ConversationHandoffRequest) (*ConversationHandoffResponse, error)
+	Assign(context.Context, *ConversationAssignRequest) (*ConversationAssignResponse, error)
+	Release(context.Context, *ConversationReleaseRequest) (*ConversationReleaseResponse, error)
+	Close(context.Context, *ConversationCloseRequest) (*ConversationCloseResponse, error)
+	Stream(*ConversationStreamRequest, ConversationService_StreamServer) error
+	mustEmbedUnimplementedConversationServiceServer()
+}
+
+// UnimplementedConversationServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedConversationServiceServer struct {
+}
+
+func (UnimplementedConversationServiceServer) List(context.Context, *ConversationListRequest) (*ConversationListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedConversationServiceServer) Handoff(context.Context, *ConversationHandoffRequest) (*ConversationHandoffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Handoff not implemented")
+}
+func (UnimplementedConversationServiceServer) Assign(context.Context, *ConversationAssignRequest) (*ConversationAssignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Assign not implemented")
+}
+func (UnimplementedConversationServiceServer) Release(context.Context, *ConversationReleaseRequest) (*ConversationReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Release not implemented")
+}
+func (UnimplementedConversationServiceServer) Close(context.Context, *ConversationCloseRequest) (*ConversationCloseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Close not implemented")
+}
+func (UnimplementedConversationServiceServer) Stream(*ConversationStreamRequest, ConversationService_StreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+func (UnimplementedConversationServiceServer) mustEmbedUnimplementedConversationServiceServer() {}
+
+// UnsafeConversationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConversationServiceServer will
+// result in compilation errors.
+type UnsafeConversationServiceServer interface {
+	mustEmbedUnimplementedConversationServiceServer()
+}
+
+func RegisterConversationServiceServer(s grpc.ServiceRegistrar, srv ConversationServiceServer) {
+	s.RegisterService(&ConversationService_ServiceDesc, srv)
+}
+
+func _ConversationService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConversationListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ConversationService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).List(ctx, req.(*ConversationListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_Handoff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConversationHandoffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).Handoff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ConversationService/Handoff",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).Handoff(ctx, req.(*ConversationHandoffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_Assign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConversationAssignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).Assign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ConversationService/Assign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).Assign(ctx, req.(*ConversationAssignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_Release_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConversationReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).Release(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ConversationService/Release",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).Release(ctx, req.(*ConversationReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_Close_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConversationCloseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).Close(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ConversationService/Close",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).Close(ctx, req.(*ConversationCloseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationService_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ConversationStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConversationServiceServer).Stream(m, &conversationServiceStreamServer{stream})
+}
+
+type ConversationService_StreamServer interface {
+	Send(*ConversationMessage) error
+	grpc.ServerStream
+}
+
+type conversationServiceStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *conversationServiceStreamServer) Send(m *ConversationMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ConversationService_ServiceDesc is the grpc.ServiceDesc for ConversationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ConversationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.ConversationService",
+	HandlerType: (*ConversationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "List",
+			Handler:    _ConversationService_List_Handler,
+		},
+		{
+			MethodName: "Handoff",
+			Handler:    _ConversationService_Handoff_Handler,
+		},
+		{
+			MethodName: "Assign",
+			Handler:    _ConversationService_Assign_Handler,
+		},
+		{
+			MethodName: "Release",
+			Handler:    _ConversationService_Release_Handler,
+		},
+		{
+			MethodName: "Close",
+			Handler:    _ConversationService_Close_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _ConversationService_Stream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "conversation.proto",
+}
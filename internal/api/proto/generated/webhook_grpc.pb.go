@@ -0,0 +1,249 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.22.2
+// source: webhook.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// WebhookServiceClient is the client API for WebhookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookServiceClient interface {
+	Create(ctx context.Context, in *WebhookCreateRequest, opts ...grpc.CallOption) (*WebhookCreateResponse, error)
+	List(ctx context.Context, in *WebhookListRequest, opts ...grpc.CallOption) (*WebhookListResponse, error)
+	Delete(ctx context.Context, in *WebhookDeleteRequest, opts ...grpc.CallOption) (*WebhookDeleteResponse, error)
+	ListDeadLetters(ctx context.Context, in *WebhookListDeadLettersRequest, opts ...grpc.CallOption) (*WebhookListDeadLettersResponse, error)
+	Redeliver(ctx context.Context, in *WebhookRedeliverRequest, opts ...grpc.CallOption) (*WebhookRedeliverResponse, error)
+}
+
+type webhookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookServiceClient(cc grpc.ClientConnInterface) WebhookServiceClient {
+	return &webhookServiceClient{cc}
+}
+
+func (c *webhookServiceClient) Create(ctx context.Context, in *WebhookCreateRequest, opts ...grpc.CallOption) (*WebhookCreateResponse, error) {
+	out := new(WebhookCreateResponse)
+	err := c.cc.Invoke(ctx, "/proto.WebhookService/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) List(ctx context.Context, in *WebhookListRequest, opts ...grpc.CallOption) (*WebhookListResponse, error) {
+	out := new(WebhookListResponse)
+	err := c.cc.Invoke(ctx, "/proto.WebhookService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) Delete(ctx context.Context, in *WebhookDeleteRequest, opts ...grpc.CallOption) (*WebhookDeleteResponse, error) {
+	out := new(WebhookDeleteResponse)
+	err := c.cc.Invoke(ctx, "/proto.WebhookService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) ListDeadLetters(ctx context.Context, in *WebhookListDeadLettersRequest, opts ...grpc.CallOption) (*WebhookListDeadLettersResponse, error) {
+	out := new(WebhookListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, "/proto.WebhookService/ListDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookServiceClient) Redeliver(ctx context.Context, in *WebhookRedeliverRequest, opts ...grpc.CallOption) (*WebhookRedeliverResponse, error) {
+	out := new(WebhookRedeliverResponse)
+	err := c.cc.Invoke(ctx, "/proto.WebhookService/Redeliver", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookServiceServer is the server API for WebhookService service.
+// All implementations must embed UnimplementedWebhookServiceServer
+// for forward compatibility
+type WebhookServiceServer interface {
+	Create(context.Context, *WebhookCreateRequest) (*WebhookCreateResponse, error)
+	List(context.Context, *WebhookListRequest) (*WebhookListResponse, error)
+	Delete(context.Context, *WebhookDeleteRequest) (*WebhookDeleteResponse, error)
+	ListDeadLetters(context.Context, *WebhookListDeadLettersRequest) (*WebhookListDeadLettersResponse, error)
+	Redeliver(context.Context, *WebhookRedeliverRequest) (*WebhookRedeliverResponse, error)
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+// UnimplementedWebhookServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWebhookServiceServer struct {
+}
+
+func (UnimplementedWebhookServiceServer) Create(context.Context, *WebhookCreateRequest) (*WebhookCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedWebhookServiceServer) List(context.Context, *WebhookListRequest) (*WebhookListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedWebhookServiceServer) Delete(context.Context, *WebhookDeleteRequest) (*WebhookDeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedWebhookServiceServer) ListDeadLetters(context.Context, *WebhookListDeadLettersRequest) (*WebhookListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedWebhookServiceServer) Redeliver(context.Context, *WebhookRedeliverRequest) (*WebhookRedeliverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Redeliver not implemented")
+}
+func (UnimplementedWebhookServiceServer) mustEmbedUnimplementedWebhookServiceServer() {}
+
+// UnsafeWebhookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookServiceServer will
+// result in compilation errors.
+type UnsafeWebhookServiceServer interface {
+	mustEmbedUnimplementedWebhookServiceServer()
+}
+
+func RegisterWebhookServiceServer(s grpc.ServiceRegistrar, srv WebhookServiceServer) {
+	s.RegisterService(&WebhookService_ServiceDesc, srv)
+}
+
+func _WebhookService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WebhookService/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).Create(ctx, req.(*WebhookCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WebhookService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).List(ctx, req.(*WebhookListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WebhookService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).Delete(ctx, req.(*WebhookDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WebhookService/ListDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).ListDeadLetters(ctx, req.(*WebhookListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookService_Redeliver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookRedeliverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookServiceServer).Redeliver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WebhookService/Redeliver",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookServiceServer).Redeliver(ctx, req.(*WebhookRedeliverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookService_ServiceDesc is the grpc.ServiceDesc for WebhookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.WebhookService",
+	HandlerType: (*WebhookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _WebhookService_Create_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _WebhookService_List_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _WebhookService_Delete_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _WebhookService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "Redeliver",
+			Handler:    _WebhookService_Redeliver_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "webhook.proto",
+}
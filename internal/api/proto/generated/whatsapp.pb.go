@@ -9,7 +9,8 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	_ "google.golang.org/protobuf/types/known/timestamppb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -181,25 +182,711 @@ type WhatsAppMessageResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	MessageUUID string `protobuf:"bytes,1,opt,name=messageUUID,proto3" json:"messageUUID,omitempty"`
+	Status      string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *WhatsAppMessageResponse) Reset() {
+	*x = WhatsAppMessageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_whatsapp_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhatsAppMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatsAppMessageResponse) ProtoMessage() {}
+
+func (x *WhatsAppMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatsAppMessageResponse.ProtoReflect.Descriptor instead.
+func (*WhatsAppMessageResponse) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WhatsAppMessageResponse) GetMessageUUID() string {
+	if x != nil {
+		return x.MessageUUID
+	}
+	return ""
+}
+
+func (x *WhatsAppMessageResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type WhatsAppMessageStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountUUID string `protobuf:"bytes,1,opt,name=accountUUID,proto3" json:"accountUUID,omitempty"`
+	MessageUUID string `protobuf:"bytes,2,opt,name=messageUUID,proto3" json:"messageUUID,omitempty"`
+}
+
+func (x *WhatsAppMessageStatusRequest) Reset() {
+	*x = WhatsAppMessageStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_whatsapp_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhatsAppMessageStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatsAppMessageStatusRequest) ProtoMessage() {}
+
+func (x *WhatsAppMessageStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatsAppMessageStatusRequest.ProtoReflect.Descriptor instead.
+func (*WhatsAppMessageStatusRequest) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *WhatsAppMessageStatusRequest) GetAccountUUID() string {
+	if x != nil {
+		return x.AccountUUID
+	}
+	return ""
+}
+
+func (x *WhatsAppMessageStatusRequest) GetMessageUUID() string {
+	if x != nil {
+		return x.MessageUUID
+	}
+	return ""
+}
+
+type WhatsAppMessageStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageUUID       string                 `protobuf:"bytes,1,opt,name=messageUUID,proto3" json:"messageUUID,omitempty"`
+	Status            string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Attempts          int32                  `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError         string                 `protobuf:"bytes,4,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	WhatsAppMessageID string                 `protobuf:"bytes,5,opt,name=whatsAppMessageID,proto3" json:"whatsAppMessageID,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	SentAt            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=sentAt,proto3" json:"sentAt,omitempty"`
+}
+
+func (x *WhatsAppMessageStatusResponse) Reset() {
+	*x = WhatsAppMessageStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_whatsapp_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhatsAppMessageStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatsAppMessageStatusResponse) ProtoMessage() {}
+
+func (x *WhatsAppMessageStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatsAppMessageStatusResponse.ProtoReflect.Descriptor instead.
+func (*WhatsAppMessageStatusResponse) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WhatsAppMessageStatusResponse) GetMessageUUID() string {
+	if x != nil {
+		return x.MessageUUID
+	}
+	return ""
+}
+
+func (x *WhatsAppMessageStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WhatsAppMessageStatusResponse) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WhatsAppMessageStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WhatsAppMessageStatusResponse) GetWhatsAppMessageID() string {
+	if x != nil {
+		return x.WhatsAppMessageID
+	}
+	return ""
+}
+
+func (x *WhatsAppMessageStatusResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WhatsAppMessageStatusResponse) GetSentAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAt
+	}
+	return nil
+}
+
+type WhatsAppReplyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountUUID string `protobuf:"bytes,1,opt,name=accountUUID,proto3" json:"accountUUID,omitempty"`
+	From        string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	Text        string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *WhatsAppReplyRequest) Reset() {
+	*x = WhatsAppReplyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_whatsapp_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhatsAppReplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatsAppReplyRequest) ProtoMessage() {}
+
+func (x *WhatsAppReplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatsAppReplyRequest.ProtoReflect.Descriptor instead.
+func (*WhatsAppReplyRequest) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WhatsAppReplyRequest) GetAccountUUID() string {
+	if x != nil {
+		return x.AccountUUID
+	}
+	return ""
+}
+
+func (x *WhatsAppReplyRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *WhatsAppReplyRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type WhatsAppReplyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MessageUUID string `protobuf:"bytes,1,opt,name=messageUUID,proto3" json:"messageUUID,omitempty"`
+	Status      string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *WhatsAppReplyResponse) Reset() {
+	*x = WhatsAppReplyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_whatsapp_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhatsAppReplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatsAppReplyResponse) ProtoMessage() {}
+
+func (x *WhatsAppReplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatsAppReplyResponse.ProtoReflect.Descriptor instead.
+func (*WhatsAppReplyResponse) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WhatsAppReplyResponse) GetMessageUUID() string {
+	if x != nil {
+		return x.MessageUUID
+	}
+	return ""
+}
+
+func (x *WhatsAppReplyResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type WhatsAppWarmupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountUUID string `protobuf:"bytes,1,opt,name=accountUUID,proto3" json:"accountUUID,omitempty"`
+}
+
+func (x *WhatsAppWarmupRequest) Reset() {
+	*x = WhatsAppWarmupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_whatsapp_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhatsAppWarmupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatsAppWarmupRequest) ProtoMessage() {}
+
+func (x *WhatsAppWarmupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatsAppWarmupRequest.ProtoReflect.Descriptor instead.
+func (*WhatsAppWarmupRequest) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WhatsAppWarmupRequest) GetAccountUUID() string {
+	if x != nil {
+		return x.AccountUUID
+	}
+	return ""
+}
+
+type WhatsAppWarmupResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policy         string                 `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	AgeDays        int32                  `protobuf:"varint,2,opt,name=ageDays,proto3" json:"ageDays,omitempty"`
+	Stage          int32                  `protobuf:"varint,3,opt,name=stage,proto3" json:"stage,omitempty"`
+	DailyAllowance int32                  `protobuf:"varint,4,opt,name=dailyAllowance,proto3" json:"dailyAllowance,omitempty"`
+	SentToday      int64                  `protobuf:"varint,5,opt,name=sentToday,proto3" json:"sentToday,omitempty"`
+	Remaining      int64                  `protobuf:"varint,6,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	WarmedUp       bool                   `protobuf:"varint,7,opt,name=warmedUp,proto3" json:"warmedUp,omitempty"`
+	StageEndsAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=stageEndsAt,proto3" json:"stageEndsAt,omitempty"`
+}
+
+func (x *WhatsAppWarmupResponse) Reset() {
+	*x = WhatsAppWarmupResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_whatsapp_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhatsAppWarmupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatsAppWarmupResponse) ProtoMessage() {}
+
+func (x *WhatsAppWarmupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatsAppWarmupResponse.ProtoReflect.Descriptor instead.
+func (*WhatsAppWarmupResponse) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WhatsAppWarmupResponse) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *WhatsAppWarmupResponse) GetAgeDays() int32 {
+	if x != nil {
+		return x.AgeDays
+	}
+	return 0
+}
+
+func (x *WhatsAppWarmupResponse) GetStage() int32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+func (x *WhatsAppWarmupResponse) GetDailyAllowance() int32 {
+	if x != nil {
+		return x.DailyAllowance
+	}
+	return 0
+}
+
+func (x *WhatsAppWarmupResponse) GetSentToday() int64 {
+	if x != nil {
+		return x.SentToday
+	}
+	return 0
+}
+
+func (x *WhatsAppWarmupResponse) GetRemaining() int64 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *WhatsAppWarmupResponse) GetWarmedUp() bool {
+	if x != nil {
+		return x.WarmedUp
+	}
+	return false
+}
+
+func (x *WhatsAppWarmupResponse) GetStageEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StageEndsAt
+	}
+	return nil
+}
+
+type WhatsAppStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountUUID string `protobuf:"bytes,1,opt,name=accountUUID,proto3" json:"accountUUID,omitempty"`
+}
+
+func (x *WhatsAppStatusRequest) Reset() {
+	*x = WhatsAppStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_whatsapp_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhatsAppStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatsAppStatusRequest) ProtoMessage() {}
+
+func (x *WhatsAppStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatsAppStatusRequest.ProtoReflect.Descriptor instead.
+func (*WhatsAppStatusRequest) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WhatsAppStatusRequest) GetAccountUUID() string {
+	if x != nil {
+		return x.AccountUUID
+	}
+	return ""
+}
+
+type WhatsAppStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	State       string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Phone       string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Attempts    int32                  `protobuf:"varint,3,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	NextRetryAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=nextRetryAt,proto3" json:"nextRetryAt,omitempty"`
+	LastError   string                 `protobuf:"bytes,5,opt,name=lastError,proto3" json:"lastError,omitempty"`
+}
+
+func (x *WhatsAppStatusResponse) Reset() {
+	*x = WhatsAppStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_whatsapp_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhatsAppStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatsAppStatusResponse) ProtoMessage() {}
+
+func (x *WhatsAppStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatsAppStatusResponse.ProtoReflect.Descriptor instead.
+func (*WhatsAppStatusResponse) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WhatsAppStatusResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *WhatsAppStatusResponse) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *WhatsAppStatusResponse) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WhatsAppStatusResponse) GetNextRetryAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRetryAt
+	}
+	return nil
+}
+
+func (x *WhatsAppStatusResponse) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+// WhatsAppQRRequest may ask for the code rendered in the given format. Size is
+// in pixels and only applies to PNG; margin is in modules. Unset options take
+// the configured defaults.
+type WhatsAppQRRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AccountUUID     string `protobuf:"bytes,1,opt,name=accountUUID,proto3" json:"accountUUID,omitempty"`
+	Format          string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	Size            int32  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Margin          int32  `protobuf:"varint,4,opt,name=margin,proto3" json:"margin,omitempty"`
+	ErrorCorrection string `protobuf:"bytes,5,opt,name=errorCorrection,proto3" json:"errorCorrection,omitempty"`
+}
+
+func (x *WhatsAppQRRequest) Reset() {
+	*x = WhatsAppQRRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_whatsapp_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WhatsAppQRRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WhatsAppQRRequest) ProtoMessage() {}
+
+func (x *WhatsAppQRRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WhatsAppQRRequest.ProtoReflect.Descriptor instead.
+func (*WhatsAppQRRequest) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WhatsAppQRRequest) GetAccountUUID() string {
+	if x != nil {
+		return x.AccountUUID
+	}
+	return ""
+}
+
+func (x *WhatsAppQRRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *WhatsAppQRRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *WhatsAppQRRequest) GetMargin() int32 {
+	if x != nil {
+		return x.Margin
+	}
+	return 0
+}
+
+func (x *WhatsAppQRRequest) GetErrorCorrection() string {
+	if x != nil {
+		return x.ErrorCorrection
+	}
+	return ""
 }
 
-func (x *WhatsAppMessageResponse) Reset() {
-	*x = WhatsAppMessageResponse{}
+type WhatsAppQRResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Qr    string `protobuf:"bytes,1,opt,name=qr,proto3" json:"qr,omitempty"`
+	Png   []byte `protobuf:"bytes,2,opt,name=png,proto3" json:"png,omitempty"`
+	Svg   string `protobuf:"bytes,3,opt,name=svg,proto3" json:"svg,omitempty"`
+	Ascii string `protobuf:"bytes,4,opt,name=ascii,proto3" json:"ascii,omitempty"`
+}
+
+func (x *WhatsAppQRResponse) Reset() {
+	*x = WhatsAppQRResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_whatsapp_proto_msgTypes[3]
+		mi := &file_whatsapp_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WhatsAppMessageResponse) String() string {
+func (x *WhatsAppQRResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WhatsAppMessageResponse) ProtoMessage() {}
+func (*WhatsAppQRResponse) ProtoMessage() {}
 
-func (x *WhatsAppMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_whatsapp_proto_msgTypes[3]
+func (x *WhatsAppQRResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -210,38 +897,64 @@ func (x *WhatsAppMessageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WhatsAppMessageResponse.ProtoReflect.Descriptor instead.
-func (*WhatsAppMessageResponse) Descriptor() ([]byte, []int) {
-	return file_whatsapp_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use WhatsAppQRResponse.ProtoReflect.Descriptor instead.
+func (*WhatsAppQRResponse) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{13}
 }
 
-type WhatsAppReplyRequest struct {
+func (x *WhatsAppQRResponse) GetQr() string {
+	if x != nil {
+		return x.Qr
+	}
+	return ""
+}
+
+func (x *WhatsAppQRResponse) GetPng() []byte {
+	if x != nil {
+		return x.Png
+	}
+	return nil
+}
+
+func (x *WhatsAppQRResponse) GetSvg() string {
+	if x != nil {
+		return x.Svg
+	}
+	return ""
+}
+
+func (x *WhatsAppQRResponse) GetAscii() string {
+	if x != nil {
+		return x.Ascii
+	}
+	return ""
+}
+
+type WhatsAppEventsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	AccountUUID string `protobuf:"bytes,1,opt,name=accountUUID,proto3" json:"accountUUID,omitempty"`
-	From        string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
-	Text        string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
 }
 
-func (x *WhatsAppReplyRequest) Reset() {
-	*x = WhatsAppReplyRequest{}
+func (x *WhatsAppEventsRequest) Reset() {
+	*x = WhatsAppEventsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_whatsapp_proto_msgTypes[4]
+		mi := &file_whatsapp_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WhatsAppReplyRequest) String() string {
+func (x *WhatsAppEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WhatsAppReplyRequest) ProtoMessage() {}
+func (*WhatsAppEventsRequest) ProtoMessage() {}
 
-func (x *WhatsAppReplyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_whatsapp_proto_msgTypes[4]
+func (x *WhatsAppEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -252,55 +965,48 @@ func (x *WhatsAppReplyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WhatsAppReplyRequest.ProtoReflect.Descriptor instead.
-func (*WhatsAppReplyRequest) Descriptor() ([]byte, []int) {
-	return file_whatsapp_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use WhatsAppEventsRequest.ProtoReflect.Descriptor instead.
+func (*WhatsAppEventsRequest) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *WhatsAppReplyRequest) GetAccountUUID() string {
+func (x *WhatsAppEventsRequest) GetAccountUUID() string {
 	if x != nil {
 		return x.AccountUUID
 	}
 	return ""
 }
 
-func (x *WhatsAppReplyRequest) GetFrom() string {
-	if x != nil {
-		return x.From
-	}
-	return ""
-}
-
-func (x *WhatsAppReplyRequest) GetText() string {
-	if x != nil {
-		return x.Text
-	}
-	return ""
-}
-
-type WhatsAppReplyResponse struct {
+type WhatsAppEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Type        string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	AccountUUID string                 `protobuf:"bytes,2,opt,name=accountUUID,proto3" json:"accountUUID,omitempty"`
+	Node        string                 `protobuf:"bytes,3,opt,name=node,proto3" json:"node,omitempty"`
+	At          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=at,proto3" json:"at,omitempty"`
+	Data        *structpb.Struct       `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	Truncated   bool                   `protobuf:"varint,6,opt,name=truncated,proto3" json:"truncated,omitempty"`
 }
 
-func (x *WhatsAppReplyResponse) Reset() {
-	*x = WhatsAppReplyResponse{}
+func (x *WhatsAppEvent) Reset() {
+	*x = WhatsAppEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_whatsapp_proto_msgTypes[5]
+		mi := &file_whatsapp_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WhatsAppReplyResponse) String() string {
+func (x *WhatsAppEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WhatsAppReplyResponse) ProtoMessage() {}
+func (*WhatsAppEvent) ProtoMessage() {}
 
-func (x *WhatsAppReplyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_whatsapp_proto_msgTypes[5]
+func (x *WhatsAppEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,12 +1017,54 @@ func (x *WhatsAppReplyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WhatsAppReplyResponse.ProtoReflect.Descriptor instead.
-func (*WhatsAppReplyResponse) Descriptor() ([]byte, []int) {
-	return file_whatsapp_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use WhatsAppEvent.ProtoReflect.Descriptor instead.
+func (*WhatsAppEvent) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{15}
 }
 
-type WhatsAppQRRequest struct {
+func (x *WhatsAppEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WhatsAppEvent) GetAccountUUID() string {
+	if x != nil {
+		return x.AccountUUID
+	}
+	return ""
+}
+
+func (x *WhatsAppEvent) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *WhatsAppEvent) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *WhatsAppEvent) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *WhatsAppEvent) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type WhatsAppPairingLinkRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -324,23 +1072,23 @@ type WhatsAppQRRequest struct {
 	AccountUUID string `protobuf:"bytes,1,opt,name=accountUUID,proto3" json:"accountUUID,omitempty"`
 }
 
-func (x *WhatsAppQRRequest) Reset() {
-	*x = WhatsAppQRRequest{}
+func (x *WhatsAppPairingLinkRequest) Reset() {
+	*x = WhatsAppPairingLinkRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_whatsapp_proto_msgTypes[6]
+		mi := &file_whatsapp_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WhatsAppQRRequest) String() string {
+func (x *WhatsAppPairingLinkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WhatsAppQRRequest) ProtoMessage() {}
+func (*WhatsAppPairingLinkRequest) ProtoMessage() {}
 
-func (x *WhatsAppQRRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_whatsapp_proto_msgTypes[6]
+func (x *WhatsAppPairingLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -351,43 +1099,44 @@ func (x *WhatsAppQRRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WhatsAppQRRequest.ProtoReflect.Descriptor instead.
-func (*WhatsAppQRRequest) Descriptor() ([]byte, []int) {
-	return file_whatsapp_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use WhatsAppPairingLinkRequest.ProtoReflect.Descriptor instead.
+func (*WhatsAppPairingLinkRequest) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *WhatsAppQRRequest) GetAccountUUID() string {
+func (x *WhatsAppPairingLinkRequest) GetAccountUUID() string {
 	if x != nil {
 		return x.AccountUUID
 	}
 	return ""
 }
 
-type WhatsAppQRResponse struct {
+type WhatsAppPairingLinkResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Qr string `protobuf:"bytes,1,opt,name=qr,proto3" json:"qr,omitempty"`
+	Url       string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
 }
 
-func (x *WhatsAppQRResponse) Reset() {
-	*x = WhatsAppQRResponse{}
+func (x *WhatsAppPairingLinkResponse) Reset() {
+	*x = WhatsAppPairingLinkResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_whatsapp_proto_msgTypes[7]
+		mi := &file_whatsapp_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *WhatsAppQRResponse) String() string {
+func (x *WhatsAppPairingLinkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WhatsAppQRResponse) ProtoMessage() {}
+func (*WhatsAppPairingLinkResponse) ProtoMessage() {}
 
-func (x *WhatsAppQRResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_whatsapp_proto_msgTypes[7]
+func (x *WhatsAppPairingLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_whatsapp_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -398,75 +1147,232 @@ func (x *WhatsAppQRResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WhatsAppQRResponse.ProtoReflect.Descriptor instead.
-func (*WhatsAppQRResponse) Descriptor() ([]byte, []int) {
-	return file_whatsapp_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use WhatsAppPairingLinkResponse.ProtoReflect.Descriptor instead.
+func (*WhatsAppPairingLinkResponse) Descriptor() ([]byte, []int) {
+	return file_whatsapp_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *WhatsAppQRResponse) GetQr() string {
+func (x *WhatsAppPairingLinkResponse) GetUrl() string {
 	if x != nil {
-		return x.Qr
+		return x.Url
 	}
 	return ""
 }
 
+func (x *WhatsAppPairingLinkResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
 var File_whatsapp_proto protoreflect.FileDescriptor
 
 var file_whatsapp_proto_rawDesc = []byte{
 	0x0a, 0x0e, 0x77, 0x68, 0x61, 0x74, 0x73, 0x61, 0x70, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3a, 0x0a, 0x16, 0x57, 0x68, 0x61, 0x74,
-	0x73, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49,
-	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
-	0x55, 0x55, 0x49, 0x44, 0x22, 0x19, 0x0a, 0x17, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x74, 0x0a, 0x16, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x74,
-	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x74,
-	0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12,
-	0x14, 0x0a, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x6d, 0x65, 0x64, 0x69, 0x61, 0x22, 0x19, 0x0a, 0x17, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70,
-	0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x60, 0x0a, 0x14, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61,
-	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72,
-	0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x12,
-	0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65,
-	0x78, 0x74, 0x22, 0x17, 0x0a, 0x15, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x35, 0x0a, 0x11, 0x57,
-	0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x51, 0x52, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55,
-	0x49, 0x44, 0x22, 0x24, 0x0a, 0x12, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x51, 0x52,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x71, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x71, 0x72, 0x32, 0xa6, 0x02, 0x0a, 0x0f, 0x57, 0x68, 0x61,
-	0x74, 0x73, 0x41, 0x70, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x07,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x44, 0x0a, 0x16, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41,
+	0x70, 0x70, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2a, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04, 0x08, 0x01, 0x10, 0x01, 0x52,
+	0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x22, 0x19, 0x0a, 0x17,
 	0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57,
-	0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41,
-	0x70, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70,
-	0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x42, 0x0a, 0x05, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57,
-	0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x02, 0x51, 0x52, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x51, 0x52, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61,
-	0x74, 0x73, 0x41, 0x70, 0x70, 0x51, 0x52, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
-	0x01, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x63, 0x72, 0x69, 0x73, 0x74, 0x69, 0x61, 0x6e, 0x63, 0x6c, 0x6c, 0x2f, 0x71, 0x72, 0x70, 0x61,
-	0x79, 0x2d, 0x77, 0x70, 0x70, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xc6, 0x01, 0x0a, 0x16, 0x57, 0x68, 0x61, 0x74,
+	0x73, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2a, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04, 0x08, 0x01, 0x10,
+	0x01, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x12, 0x18,
+	0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04,
+	0x08, 0x01, 0x18, 0x01, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1b, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xc2, 0xf3, 0x18, 0x03, 0x28, 0x80, 0x20, 0x52,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x36, 0x0a, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0c, 0x42, 0x20, 0xc2, 0xf3, 0x18, 0x1c, 0x30, 0x80, 0x80, 0xc0, 0x02, 0x3a,
+	0x09, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x2f, 0x70, 0x6e, 0x67, 0x3a, 0x0a, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x2f, 0x6a, 0x70, 0x65, 0x67, 0x52, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x3a, 0x11, 0xc2,
+	0xf3, 0x18, 0x0d, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x0a, 0x05, 0x6d, 0x65, 0x64, 0x69, 0x61,
+	0x22, 0x53, 0x0a, 0x17, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x55, 0x55, 0x49, 0x44, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x76, 0x0a, 0x1c, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70,
+	0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04,
+	0x08, 0x01, 0x10, 0x01, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49,
+	0x44, 0x12, 0x2a, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x55, 0x55, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04, 0x10, 0x01, 0x08, 0x01,
+	0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x55, 0x55, 0x49, 0x44, 0x22, 0xaf, 0x02,
+	0x0a, 0x1d, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x55, 0x55, 0x49,
+	0x44, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x74, 0x74,
+	0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x11, 0x77, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x44, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x77, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49,
+	0x44, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x73,
+	0x65, 0x6e, 0x74, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x74, 0x41, 0x74, 0x22,
+	0x7f, 0x0a, 0x14, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xc2, 0xf3,
+	0x18, 0x04, 0x08, 0x01, 0x10, 0x01, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55,
+	0x55, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04, 0x18, 0x01, 0x08, 0x01, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x1d, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x09, 0xc2, 0xf3, 0x18, 0x05, 0x28, 0x80, 0x20, 0x08, 0x01, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x22, 0x51, 0x0a, 0x15, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x55, 0x55, 0x49, 0x44, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0x43, 0x0a, 0x15, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x57,
+	0x61, 0x72, 0x6d, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x0b,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04, 0x10, 0x01, 0x08, 0x01, 0x52, 0x0b, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x22, 0x9e, 0x02, 0x0a, 0x16, 0x57, 0x68, 0x61,
+	0x74, 0x73, 0x41, 0x70, 0x70, 0x57, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x67, 0x65, 0x44, 0x61, 0x79, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x61, 0x67,
+	0x65, 0x44, 0x61, 0x79, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x64,
+	0x61, 0x69, 0x6c, 0x79, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x64, 0x61, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x64, 0x61,
+	0x79, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12,
+	0x1a, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6d, 0x65, 0x64, 0x55, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6d, 0x65, 0x64, 0x55, 0x70, 0x12, 0x3c, 0x0a, 0x0b, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x73, 0x41, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x73, 0x74,
+	0x61, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x73, 0x41, 0x74, 0x22, 0x43, 0x0a, 0x15, 0x57, 0x68, 0x61,
+	0x74, 0x73, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2a, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49,
+	0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04, 0x08, 0x01, 0x10,
+	0x01, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x22, 0xbc,
+	0x01, 0x0a, 0x16, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74,
+	0x73, 0x12, 0x3c, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x65, 0x74, 0x72, 0x79, 0x41, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xe6, 0x01,
+	0x0a, 0x11, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x51, 0x52, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55,
+	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04, 0x10, 0x01,
+	0x08, 0x01, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x12,
+	0x2d, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x15, 0xc2, 0xf3, 0x18, 0x11, 0x42, 0x03, 0x70, 0x6e, 0x67, 0x42, 0x03, 0x73, 0x76, 0x67, 0x42,
+	0x05, 0x61, 0x73, 0x63, 0x69, 0x69, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x67, 0x69, 0x6e, 0x12, 0x4a, 0x0a, 0x0f, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x20, 0xc2, 0xf3, 0x18, 0x1c, 0x42, 0x03, 0x6c, 0x6f, 0x77, 0x42, 0x06,
+	0x6d, 0x65, 0x64, 0x69, 0x75, 0x6d, 0x42, 0x04, 0x68, 0x69, 0x67, 0x68, 0x42, 0x07, 0x68, 0x69,
+	0x67, 0x68, 0x65, 0x73, 0x74, 0x52, 0x0f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x72, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5e, 0x0a, 0x12, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41,
+	0x70, 0x70, 0x51, 0x52, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x71, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x71, 0x72, 0x12, 0x10, 0x0a, 0x03,
+	0x70, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x70, 0x6e, 0x67, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x76, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x76, 0x67,
+	0x12, 0x14, 0x0a, 0x05, 0x61, 0x73, 0x63, 0x69, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x61, 0x73, 0x63, 0x69, 0x69, 0x22, 0x43, 0x0a, 0x15, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41,
+	0x70, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x2a, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04, 0x10, 0x01, 0x08, 0x01, 0x52, 0x0b,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x22, 0xd0, 0x01, 0x0a, 0x0d,
+	0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55,
+	0x55, 0x49, 0x44, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x2a, 0x0a, 0x02, 0x61, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x02, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0x48,
+	0x0a, 0x1a, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x50, 0x61, 0x69, 0x72, 0x69, 0x6e,
+	0x67, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x0b,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x08, 0xc2, 0xf3, 0x18, 0x04, 0x08, 0x01, 0x10, 0x01, 0x52, 0x0b, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x55, 0x55, 0x49, 0x44, 0x22, 0x69, 0x0a, 0x1b, 0x57, 0x68, 0x61, 0x74,
+	0x73, 0x41, 0x70, 0x70, 0x50, 0x61, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6e, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x38, 0x0a, 0x09, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
+	0x73, 0x41, 0x74, 0x32, 0xa6, 0x05, 0x0a, 0x0f, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73,
+	0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41,
+	0x70, 0x70, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x48, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x05, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61,
+	0x74, 0x73, 0x41, 0x70, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41,
+	0x70, 0x70, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3b, 0x0a, 0x02, 0x51, 0x52, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68,
+	0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x51, 0x52, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70,
+	0x51, 0x52, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x5a, 0x0a, 0x0d,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x23, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73,
+	0x41, 0x70, 0x70, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x06, 0x57, 0x61, 0x72, 0x6d,
+	0x75, 0x70, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73,
+	0x41, 0x70, 0x70, 0x57, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70,
+	0x70, 0x57, 0x61, 0x72, 0x6d, 0x75, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x45, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x06, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70,
+	0x70, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x54, 0x0a, 0x0b, 0x50, 0x61, 0x69, 0x72, 0x69, 0x6e,
+	0x67, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x68,
+	0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x50, 0x61, 0x69, 0x72, 0x69, 0x6e, 0x67, 0x4c, 0x69, 0x6e,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x57, 0x68, 0x61, 0x74, 0x73, 0x41, 0x70, 0x70, 0x50, 0x61, 0x69, 0x72, 0x69, 0x6e, 0x67,
+	0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x28, 0x5a, 0x26,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x72, 0x69, 0x73, 0x74,
+	0x69, 0x61, 0x6e, 0x63, 0x6c, 0x6c, 0x2f, 0x71, 0x72, 0x70, 0x61, 0x79, 0x2d, 0x77, 0x70, 0x70,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -481,31 +1387,60 @@ func file_whatsapp_proto_rawDescGZIP() []byte {
 	return file_whatsapp_proto_rawDescData
 }
 
-var file_whatsapp_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_whatsapp_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_whatsapp_proto_goTypes = []interface{}{
-	(*WhatsAppConnectRequest)(nil),  // 0: proto.WhatsAppConnectRequest
-	(*WhatsAppConnectResponse)(nil), // 1: proto.WhatsAppConnectResponse
-	(*WhatsAppMessageRequest)(nil),  // 2: proto.WhatsAppMessageRequest
-	(*WhatsAppMessageResponse)(nil), // 3: proto.WhatsAppMessageResponse
-	(*WhatsAppReplyRequest)(nil),    // 4: proto.WhatsAppReplyRequest
-	(*WhatsAppReplyResponse)(nil),   // 5: proto.WhatsAppReplyResponse
-	(*WhatsAppQRRequest)(nil),       // 6: proto.WhatsAppQRRequest
-	(*WhatsAppQRResponse)(nil),      // 7: proto.WhatsAppQRResponse
+	(*WhatsAppConnectRequest)(nil),        // 0: proto.WhatsAppConnectRequest
+	(*WhatsAppConnectResponse)(nil),       // 1: proto.WhatsAppConnectResponse
+	(*WhatsAppMessageRequest)(nil),        // 2: proto.WhatsAppMessageRequest
+	(*WhatsAppMessageResponse)(nil),       // 3: proto.WhatsAppMessageResponse
+	(*WhatsAppMessageStatusRequest)(nil),  // 4: proto.WhatsAppMessageStatusRequest
+	(*WhatsAppMessageStatusResponse)(nil), // 5: proto.WhatsAppMessageStatusResponse
+	(*WhatsAppReplyRequest)(nil),          // 6: proto.WhatsAppReplyRequest
+	(*WhatsAppReplyResponse)(nil),         // 7: proto.WhatsAppReplyResponse
+	(*WhatsAppWarmupRequest)(nil),         // 8: proto.WhatsAppWarmupRequest
+	(*WhatsAppWarmupResponse)(nil),        // 9: proto.WhatsAppWarmupResponse
+	(*WhatsAppStatusRequest)(nil),         // 10: proto.WhatsAppStatusRequest
+	(*WhatsAppStatusResponse)(nil),        // 11: proto.WhatsAppStatusResponse
+	(*WhatsAppQRRequest)(nil),             // 12: proto.WhatsAppQRRequest
+	(*WhatsAppQRResponse)(nil),            // 13: proto.WhatsAppQRResponse
+	(*WhatsAppEventsRequest)(nil),         // 14: proto.WhatsAppEventsRequest
+	(*WhatsAppEvent)(nil),                 // 15: proto.WhatsAppEvent
+	(*WhatsAppPairingLinkRequest)(nil),    // 16: proto.WhatsAppPairingLinkRequest
+	(*WhatsAppPairingLinkResponse)(nil),   // 17: proto.WhatsAppPairingLinkResponse
+	(*timestamppb.Timestamp)(nil),         // 18: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),               // 19: google.protobuf.Struct
 }
 var file_whatsapp_proto_depIdxs = []int32{
-	0, // 0: proto.WhatsAppService.Connect:input_type -> proto.WhatsAppConnectRequest
-	2, // 1: proto.WhatsAppService.Message:input_type -> proto.WhatsAppMessageRequest
-	4, // 2: proto.WhatsAppService.Reply:input_type -> proto.WhatsAppReplyRequest
-	6, // 3: proto.WhatsAppService.QR:input_type -> proto.WhatsAppQRRequest
-	1, // 4: proto.WhatsAppService.Connect:output_type -> proto.WhatsAppConnectResponse
-	3, // 5: proto.WhatsAppService.Message:output_type -> proto.WhatsAppMessageResponse
-	5, // 6: proto.WhatsAppService.Reply:output_type -> proto.WhatsAppReplyResponse
-	7, // 7: proto.WhatsAppService.QR:output_type -> proto.WhatsAppQRResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	18, // 0: proto.WhatsAppMessageStatusResponse.createdAt:type_name -> google.protobuf.Timestamp
+	18, // 1: proto.WhatsAppMessageStatusResponse.sentAt:type_name -> google.protobuf.Timestamp
+	18, // 2: proto.WhatsAppWarmupResponse.stageEndsAt:type_name -> google.protobuf.Timestamp
+	18, // 3: proto.WhatsAppStatusResponse.nextRetryAt:type_name -> google.protobuf.Timestamp
+	18, // 4: proto.WhatsAppEvent.at:type_name -> google.protobuf.Timestamp
+	19, // 5: proto.WhatsAppEvent.data:type_name -> google.protobuf.Struct
+	18, // 6: proto.WhatsAppPairingLinkResponse.expiresAt:type_name -> google.protobuf.Timestamp
+	0,  // 7: proto.WhatsAppService.Connect:input_type -> proto.WhatsAppConnectRequest
+	2,  // 8: proto.WhatsAppService.Message:input_type -> proto.WhatsAppMessageRequest
+	6,  // 9: proto.WhatsAppService.Reply:input_type -> proto.WhatsAppReplyRequest
+	12, // 10: proto.WhatsAppService.QR:input_type -> proto.WhatsAppQRRequest
+	4,  // 11: proto.WhatsAppService.MessageStatus:input_type -> proto.WhatsAppMessageStatusRequest
+	8,  // 12: proto.WhatsAppService.Warmup:input_type -> proto.WhatsAppWarmupRequest
+	10, // 13: proto.WhatsAppService.Status:input_type -> proto.WhatsAppStatusRequest
+	14, // 14: proto.WhatsAppService.Events:input_type -> proto.WhatsAppEventsRequest
+	16, // 15: proto.WhatsAppService.PairingLink:input_type -> proto.WhatsAppPairingLinkRequest
+	1,  // 16: proto.WhatsAppService.Connect:output_type -> proto.WhatsAppConnectResponse
+	3,  // 17: proto.WhatsAppService.Message:output_type -> proto.WhatsAppMessageResponse
+	7,  // 18: proto.WhatsAppService.Reply:output_type -> proto.WhatsAppReplyResponse
+	13, // 19: proto.WhatsAppService.QR:output_type -> proto.WhatsAppQRResponse
+	5,  // 20: proto.WhatsAppService.MessageStatus:output_type -> proto.WhatsAppMessageStatusResponse
+	9,  // 21: proto.WhatsAppService.Warmup:output_type -> proto.WhatsAppWarmupResponse
+	11, // 22: proto.WhatsAppService.Status:output_type -> proto.WhatsAppStatusResponse
+	15, // 23: proto.WhatsAppService.Events:output_type -> proto.WhatsAppEvent
+	17, // 24: proto.WhatsAppService.PairingLink:output_type -> proto.WhatsAppPairingLinkResponse
+	16, // [16:25] is the sub-list for method output_type
+	7,  // [7:16] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_whatsapp_proto_init() }
@@ -513,6 +1448,7 @@ func file_whatsapp_proto_init() {
 	if File_whatsapp_proto != nil {
 		return
 	}
+	file_validate_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_whatsapp_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WhatsAppConnectRequest); i {
@@ -563,7 +1499,7 @@ func file_whatsapp_proto_init() {
 			}
 		}
 		file_whatsapp_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WhatsAppReplyRequest); i {
+			switch v := v.(*WhatsAppMessageStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -575,7 +1511,7 @@ func file_whatsapp_proto_init() {
 			}
 		}
 		file_whatsapp_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WhatsAppReplyResponse); i {
+			switch v := v.(*WhatsAppMessageStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -587,7 +1523,7 @@ func file_whatsapp_proto_init() {
 			}
 		}
 		file_whatsapp_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WhatsAppQRRequest); i {
+			switch v := v.(*WhatsAppReplyRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -599,6 +1535,78 @@ func file_whatsapp_proto_init() {
 			}
 		}
 		file_whatsapp_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhatsAppReplyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_whatsapp_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhatsAppWarmupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_whatsapp_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhatsAppWarmupResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_whatsapp_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhatsAppStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_whatsapp_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhatsAppStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_whatsapp_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhatsAppQRRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_whatsapp_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WhatsAppQRResponse); i {
 			case 0:
 				return &v.state
@@ -610,6 +1618,54 @@ func file_whatsapp_proto_init() {
 				return nil
 			}
 		}
+		file_whatsapp_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhatsAppEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_whatsapp_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhatsAppEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_whatsapp_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhatsAppPairingLinkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_whatsapp_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WhatsAppPairingLinkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -617,7 +1673,7 @@ func file_whatsapp_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_whatsapp_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,11 @@ type WhatsAppServiceClient interface {
 	Message(ctx context.Context, in *WhatsAppMessageRequest, opts ...grpc.CallOption) (*WhatsAppMessageResponse, error)
 	Reply(ctx context.Context, in *WhatsAppReplyRequest, opts ...grpc.CallOption) (*WhatsAppReplyResponse, error)
 	QR(ctx context.Context, in *WhatsAppQRRequest, opts ...grpc.CallOption) (WhatsAppService_QRClient, error)
+	MessageStatus(ctx context.Context, in *WhatsAppMessageStatusRequest, opts ...grpc.CallOption) (*WhatsAppMessageStatusResponse, error)
+	Warmup(ctx context.Context, in *WhatsAppWarmupRequest, opts ...grpc.CallOption) (*WhatsAppWarmupResponse, error)
+	Status(ctx context.Context, in *WhatsAppStatusRequest, opts ...grpc.CallOption) (*WhatsAppStatusResponse, error)
+	Events(ctx context.Context, in *WhatsAppEventsRequest, opts ...grpc.CallOption) (WhatsAppService_EventsClient, error)
+	PairingLink(ctx context.Context, in *WhatsAppPairingLinkRequest, opts ...grpc.CallOption) (*WhatsAppPairingLinkResponse, error)
 }
 
 type whatsAppServiceClient struct {
@@ -95,6 +100,74 @@ func (x *whatsAppServiceQRClient) Recv() (*WhatsAppQRResponse, error) {
 	return m, nil
 }
 
+func (c *whatsAppServiceClient) MessageStatus(ctx context.Context, in *WhatsAppMessageStatusRequest, opts ...grpc.CallOption) (*WhatsAppMessageStatusResponse, error) {
+	out := new(WhatsAppMessageStatusResponse)
+	err := c.cc.Invoke(ctx, "/proto.WhatsAppService/MessageStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *whatsAppServiceClient) Warmup(ctx context.Context, in *WhatsAppWarmupRequest, opts ...grpc.CallOption) (*WhatsAppWarmupResponse, error) {
+	out := new(WhatsAppWarmupResponse)
+	err := c.cc.Invoke(ctx, "/proto.WhatsAppService/Warmup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *whatsAppServiceClient) Status(ctx context.Context, in *WhatsAppStatusRequest, opts ...grpc.CallOption) (*WhatsAppStatusResponse, error) {
+	out := new(WhatsAppStatusResponse)
+	err := c.cc.Invoke(ctx, "/proto.WhatsAppService/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *whatsAppServiceClient) Events(ctx context.Context, in *WhatsAppEventsRequest, opts ...grpc.CallOption) (WhatsAppService_EventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &WhatsAppService_ServiceDesc.Streams[1], "/proto.WhatsAppService/Events", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &whatsAppServiceEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WhatsAppService_EventsClient interface {
+	Recv() (*WhatsAppEvent, error)
+	grpc.ClientStream
+}
+
+type whatsAppServiceEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *whatsAppServiceEventsClient) Recv() (*WhatsAppEvent, error) {
+	m := new(WhatsAppEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *whatsAppServiceClient) PairingLink(ctx context.Context, in *WhatsAppPairingLinkRequest, opts ...grpc.CallOption) (*WhatsAppPairingLinkResponse, error) {
+	out := new(WhatsAppPairingLinkResponse)
+	err := c.cc.Invoke(ctx, "/proto.WhatsAppService/PairingLink", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WhatsAppServiceServer is the server API for WhatsAppService service.
 // All implementations must embed UnimplementedWhatsAppServiceServer
 // for forward compatibility
@@ -103,6 +176,11 @@ type WhatsAppServiceServer interface {
 	Message(context.Context, *WhatsAppMessageRequest) (*WhatsAppMessageResponse, error)
 	Reply(context.Context, *WhatsAppReplyRequest) (*WhatsAppReplyResponse, error)
 	QR(*WhatsAppQRRequest, WhatsAppService_QRServer) error
+	MessageStatus(context.Context, *WhatsAppMessageStatusRequest) (*WhatsAppMessageStatusResponse, error)
+	Warmup(context.Context, *WhatsAppWarmupRequest) (*WhatsAppWarmupResponse, error)
+	Status(context.Context, *WhatsAppStatusRequest) (*WhatsAppStatusResponse, error)
+	Events(*WhatsAppEventsRequest, WhatsAppService_EventsServer) error
+	PairingLink(context.Context, *WhatsAppPairingLinkRequest) (*WhatsAppPairingLinkResponse, error)
 	mustEmbedUnimplementedWhatsAppServiceServer()
 }
 
@@ -122,6 +200,21 @@ func (UnimplementedWhatsAppServiceServer) Reply(context.Context, *WhatsAppReplyR
 func (UnimplementedWhatsAppServiceServer) QR(*WhatsAppQRRequest, WhatsAppService_QRServer) error {
 	return status.Errorf(codes.Unimplemented, "method QR not implemented")
 }
+func (UnimplementedWhatsAppServiceServer) MessageStatus(context.Context, *WhatsAppMessageStatusRequest) (*WhatsAppMessageStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MessageStatus not implemented")
+}
+func (UnimplementedWhatsAppServiceServer) Warmup(context.Context, *WhatsAppWarmupRequest) (*WhatsAppWarmupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Warmup not implemented")
+}
+func (UnimplementedWhatsAppServiceServer) Status(context.Context, *WhatsAppStatusRequest) (*WhatsAppStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedWhatsAppServiceServer) Events(*WhatsAppEventsRequest, WhatsAppService_EventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+}
+func (UnimplementedWhatsAppServiceServer) PairingLink(context.Context, *WhatsAppPairingLinkRequest) (*WhatsAppPairingLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PairingLink not implemented")
+}
 func (UnimplementedWhatsAppServiceServer) mustEmbedUnimplementedWhatsAppServiceServer() {}
 
 // UnsafeWhatsAppServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -210,6 +303,99 @@ func (x *whatsAppServiceQRServer) Send(m *WhatsAppQRResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _WhatsAppService_MessageStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WhatsAppMessageStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WhatsAppServiceServer).MessageStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WhatsAppService/MessageStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WhatsAppServiceServer).MessageStatus(ctx, req.(*WhatsAppMessageStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WhatsAppService_Warmup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WhatsAppWarmupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WhatsAppServiceServer).Warmup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WhatsAppService/Warmup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WhatsAppServiceServer).Warmup(ctx, req.(*WhatsAppWarmupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WhatsAppService_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WhatsAppStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WhatsAppServiceServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WhatsAppService/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WhatsAppServiceServer).Status(ctx, req.(*WhatsAppStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WhatsAppService_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WhatsAppEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WhatsAppServiceServer).Events(m, &whatsAppServiceEventsServer{stream})
+}
+
+type WhatsAppService_EventsServer interface {
+	Send(*WhatsAppEvent) error
+	grpc.ServerStream
+}
+
+type whatsAppServiceEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *whatsAppServiceEventsServer) Send(m *WhatsAppEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _WhatsAppService_PairingLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WhatsAppPairingLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WhatsAppServiceServer).PairingLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.WhatsAppService/PairingLink",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WhatsAppServiceServer).PairingLink(ctx, req.(*WhatsAppPairingLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WhatsAppService_ServiceDesc is the grpc.ServiceDesc for WhatsAppService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +415,22 @@ var WhatsAppService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Reply",
 			Handler:    _WhatsAppService_Reply_Handler,
 		},
+		{
+			MethodName: "MessageStatus",
+			Handler:    _WhatsAppService_MessageStatus_Handler,
+		},
+		{
+			MethodName: "Warmup",
+			Handler:    _WhatsAppService_Warmup_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _WhatsAppService_Status_Handler,
+		},
+		{
+			MethodName: "PairingLink",
+			Handler:    _WhatsAppService_PairingLink_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -236,6 +438,11 @@ var WhatsAppService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _WhatsAppService_QR_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Events",
+			Handler:       _WhatsAppService_Events_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "whatsapp.proto",
 }
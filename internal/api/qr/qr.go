@@ -0,0 +1,182 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/skip2/go-qrcode"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+const (
+	// quietZone is the margin, in modules, the QR code specification asks for.
+	quietZone = 4
+	// defaultSize is the size, in pixels, of the PNG images when none is given.
+	defaultSize = 256
+	// MaxSize bounds the size of the PNG images.
+	MaxSize = 2048
+	// MaxMargin bounds the margin of the rendered codes.
+	MaxMargin = 16
+)
+
+// Levels lists the error correction levels, by name.
+var Levels = map[string]qrcode.RecoveryLevel{
+	"low":     qrcode.Low,
+	"medium":  qrcode.Medium,
+	"high":    qrcode.High,
+	"highest": qrcode.Highest,
+}
+
+// Options configures the rendering of a QR code. Zero values fall back to a
+// 256 pixels image, the quiet zone of the specification and the medium level.
+type Options struct {
+	// Size is the width and height of the PNG images, in pixels, 0 for the default.
+	Size int
+	// Margin is the quiet zone around the code, in modules, 0 for the default.
+	Margin int
+	// Level is the error correction level, one of the Levels.
+	Level string
+}
+
+// Validate reports options out of their bounds.
+func (o Options) Validate() error {
+	_, err := o.normalize()
+	return err
+}
+
+// normalize applies the defaults to the zero values and checks the bounds.
+func (o Options) normalize() (Options, error) {
+	if o.Size == 0 {
+		o.Size = defaultSize
+	}
+	if o.Margin == 0 {
+		o.Margin = quietZone
+	}
+	if o.Level == "" {
+		o.Level = "medium"
+	}
+	if o.Size < 0 || o.Size > MaxSize {
+		return o, fmt.Errorf("size must be between 0, for the default, and %d", MaxSize)
+	}
+	if o.Margin < 0 || o.Margin > MaxMargin {
+		return o, fmt.Errorf("margin must be between 0, for the default, and %d", MaxMargin)
+	}
+	if _, ok := Levels[o.Level]; !ok {
+		return o, fmt.Errorf("unknown error correction level %q", o.Level)
+	}
+	return o, nil
+}
+
+// bitmap encodes the content, surrounding it with the margin. Dark modules are true.
+func bitmap(content string, opts Options) ([][]bool, Options, error) {
+	opts, err := opts.normalize()
+	if err != nil {
+		return nil, opts, err
+	}
+	code, err := qrcode.New(content, Levels[opts.Level])
+	if err != nil {
+		return nil, opts, err
+	}
+	code.DisableBorder = true
+	modules := code.Bitmap()
+	n := len(modules) + 2*opts.Margin
+	bits := make([][]bool, n)
+	for y := range bits {
+		bits[y] = make([]bool, n)
+	}
+	for y, row := range modules {
+		copy(bits[y+opts.Margin][opts.Margin:], row)
+	}
+	return bits, opts, nil
+}
+
+// PNG renders the content as a PNG image of opts.Size by opts.Size pixels, or
+// of one pixel per module when the code does not fit in that size.
+func PNG(content string, opts Options) ([]byte, error) {
+	bits, opts, err := bitmap(content, opts)
+	if err != nil {
+		return nil, err
+	}
+	n := len(bits)
+	size := opts.Size
+	if size < n {
+		size = n
+	}
+	scale := size / n
+	offset := (size - n*scale) / 2
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{color.White, color.Black})
+	for y, row := range bits {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(offset+x*scale+dx, offset+y*scale+dy, 1)
+				}
+			}
+		}
+	}
+	buf := new(bytes.Buffer)
+	err = png.Encode(buf, img)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG renders the content as a scalable SVG image, one unit per module.
+func SVG(content string, opts Options) (string, error) {
+	bits, _, err := bitmap(content, opts)
+	if err != nil {
+		return "", err
+	}
+	n := len(bits)
+
+	b := new(strings.Builder)
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, n, n)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, n, n)
+	for y, row := range bits {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(b, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.String(), nil
+}
+
+// ASCII renders the content as text for terminals, packing two rows of modules
+// per line with half blocks. Light modules are drawn, so that the code scans on
+// the dark background of most terminals.
+func ASCII(content string, opts Options) (string, error) {
+	bits, _, err := bitmap(content, opts)
+	if err != nil {
+		return "", err
+	}
+	n := len(bits)
+
+	b := new(strings.Builder)
+	for y := 0; y < n; y += 2 {
+		for x := 0; x < n; x++ {
+			top := !bits[y][x]
+			bottom := y+1 < n && !bits[y+1][x]
+			switch {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
@@ -2,9 +2,15 @@ package server
 
 import (
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	proto "qrpay-wpp/internal/api/proto/generated"
 )
 
 func (s *Server) registerServices(grpcServer *grpc.Server) {
 	proto.RegisterWhatsAppServiceServer(grpcServer, s.handlers.wpp)
+	proto.RegisterConversationServiceServer(grpcServer, s.handlers.conv)
+	proto.RegisterConsentServiceServer(grpcServer, s.handlers.consent)
+	proto.RegisterWebhookServiceServer(grpcServer, s.handlers.webhook)
+	proto.RegisterAdminServiceServer(grpcServer, s.handlers.admin)
+	healthpb.RegisterHealthServer(grpcServer, s.handlers.health)
 }
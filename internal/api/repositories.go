@@ -6,7 +6,19 @@ import (
 )
 
 type repositories struct {
-	wpp repository.WhatsApp
+	wpp         repository.WhatsApp
+	flow        repository.FlowSession
+	conv        repository.Conversation
+	consent     repository.Consent
+	suppression repository.Suppression
+	webhook     repository.Webhook
+	delivery    repository.WebhookDelivery
+	deadLetter  repository.WebhookDeadLetter
+	outbox      repository.Outbox
+	ban         repository.Ban
+	lease       repository.AccountLease
+	node        repository.Node
+	apiKey      repository.APIKey
 }
 
 func (s *Server) createRepositories() error {
@@ -14,5 +26,53 @@ func (s *Server) createRepositories() error {
 	if err := s.repos.wpp.Migrate(s.context); err != nil {
 		return fmt.Errorf("unable to migrate whatsapp repository: %v", err)
 	}
+	s.repos.flow = repository.NewFlowSession(s.db)
+	if err := s.repos.flow.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate flow session repository: %v", err)
+	}
+	s.repos.conv = repository.NewConversation(s.db)
+	if err := s.repos.conv.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate conversation repository: %v", err)
+	}
+	s.repos.consent = repository.NewConsent(s.db)
+	if err := s.repos.consent.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate consent repository: %v", err)
+	}
+	s.repos.suppression = repository.NewSuppression(s.db)
+	if err := s.repos.suppression.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate suppression repository: %v", err)
+	}
+	s.repos.webhook = repository.NewWebhook(s.db)
+	if err := s.repos.webhook.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate webhook repository: %v", err)
+	}
+	s.repos.delivery = repository.NewWebhookDelivery(s.db)
+	if err := s.repos.delivery.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate webhook delivery repository: %v", err)
+	}
+	s.repos.deadLetter = repository.NewWebhookDeadLetter(s.db)
+	if err := s.repos.deadLetter.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate webhook dead letter repository: %v", err)
+	}
+	s.repos.outbox = repository.NewOutbox(s.db)
+	if err := s.repos.outbox.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate outbox repository: %v", err)
+	}
+	s.repos.ban = repository.NewBan(s.db)
+	if err := s.repos.ban.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate ban repository: %v", err)
+	}
+	s.repos.lease = repository.NewAccountLease(s.db)
+	if err := s.repos.lease.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate account lease repository: %v", err)
+	}
+	s.repos.node = repository.NewNode(s.db)
+	if err := s.repos.node.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate node repository: %v", err)
+	}
+	s.repos.apiKey = repository.NewAPIKey(s.db)
+	if err := s.repos.apiKey.Migrate(s.context); err != nil {
+		return fmt.Errorf("unable to migrate api key repository: %v", err)
+	}
 	return nil
 }
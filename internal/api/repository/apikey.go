@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+type APIKey interface {
+	Migrater
+	TCreater[model.APIKey]
+	TUpdater[model.APIKey]
+	TGetterByUUID[model.APIKey]
+	TGetterAll[model.APIKey]
+	TGetByHash(ctx context.Context, tx pgx.Tx, hash string) (*model.APIKey, error)
+}
+
+type apiKey struct {
+	db *pgxpool.Pool
+}
+
+func NewAPIKey(db *pgxpool.Pool) APIKey {
+	return &apiKey{db: db}
+}
+
+func (r *apiKey) TCreate(ctx context.Context, tx pgx.Tx, k *model.APIKey) error {
+	k.UUID = uuid.New().String()
+	k.CreatedAt = time.Now().UTC()
+	k.UpdatedAt = time.Now().UTC()
+	if k.Accounts == nil {
+		k.Accounts = []string{}
+	}
+	query := `INSERT INTO api_keys (uuid, name, prefix, hash, accounts, admin, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+	id, err := tCreate(ctx, tx, query, k.UUID, k.Name, k.Prefix, k.Hash, k.Accounts, k.Admin, k.CreatedAt, k.UpdatedAt)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	k.ID = id
+	return nil
+}
+
+func (r *apiKey) TUpdate(ctx context.Context, tx pgx.Tx, k *model.APIKey) error {
+	k.UpdatedAt = time.Now().UTC()
+	query := `UPDATE api_keys SET name = $2, accounts = $3, admin = $4, last_used_at = $5, revoked_at = $6, updated_at = $7 WHERE id = $1`
+	return tUpdate(ctx, tx, query, k.ID, k.Name, k.Accounts, k.Admin, k.LastUsedAt, k.RevokedAt, k.UpdatedAt)
+}
+
+func (r *apiKey) TGetByUUID(ctx context.Context, tx pgx.Tx, uuid string) (*model.APIKey, error) {
+	query := `SELECT * FROM api_keys WHERE uuid = $1`
+	return tGet[model.APIKey](ctx, tx, query, uuid)
+}
+
+func (r *apiKey) TGetAll(ctx context.Context, tx pgx.Tx) ([]*model.APIKey, error) {
+	query := `SELECT * FROM api_keys ORDER BY created_at`
+	return tGetAll[model.APIKey](ctx, tx, query)
+}
+
+func (r *apiKey) TGetByHash(ctx context.Context, tx pgx.Tx, hash string) (*model.APIKey, error) {
+	query := `SELECT * FROM api_keys WHERE hash = $1`
+	return tGet[model.APIKey](ctx, tx, query, hash)
+}
+
+func (r *apiKey) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS api_keys (
+				id SERIAL PRIMARY KEY,
+				uuid VARCHAR(255) NOT NULL UNIQUE,
+				name VARCHAR(255) NOT NULL,
+				prefix VARCHAR(16) NOT NULL,
+				hash VARCHAR(64) NOT NULL UNIQUE,
+				accounts TEXT[] NOT NULL DEFAULT '{}',
+				admin BOOLEAN NOT NULL DEFAULT FALSE,
+				last_used_at TIMESTAMP,
+				revoked_at TIMESTAMP,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL
+			)`
+	return migrate(ctx, r.db, query)
+}
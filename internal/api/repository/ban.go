@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+type Ban interface {
+	Migrater
+	TCreater[model.Ban]
+	TUpdater[model.Ban]
+	TGetOpenByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) (*model.Ban, error)
+	TGetAllByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) ([]*model.Ban, error)
+}
+
+type ban struct {
+	db *pgxpool.Pool
+}
+
+func NewBan(db *pgxpool.Pool) Ban {
+	return &ban{db: db}
+}
+
+func (r *ban) TCreate(ctx context.Context, tx pgx.Tx, b *model.Ban) error {
+	b.UUID = uuid.New().String()
+	b.CreatedAt = time.Now().UTC()
+	query := `INSERT INTO ban_history (uuid, account_uuid, phone, code, reason, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	id, err := tCreate(ctx, tx, query, b.UUID, b.AccountUUID, b.Phone, b.Code, b.Reason, b.ExpiresAt, b.CreatedAt)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	b.ID = id
+	return nil
+}
+
+func (r *ban) TUpdate(ctx context.Context, tx pgx.Tx, b *model.Ban) error {
+	query := `UPDATE ban_history SET expires_at = $2, lifted_at = $3 WHERE id = $1`
+	return tUpdate(ctx, tx, query, b.ID, b.ExpiresAt, b.LiftedAt)
+}
+
+func (r *ban) TGetOpenByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) (*model.Ban, error) {
+	query := `SELECT * FROM ban_history WHERE account_uuid = $1 AND lifted_at IS NULL ORDER BY created_at DESC LIMIT 1`
+	return tGet[model.Ban](ctx, tx, query, accountUUID)
+}
+
+func (r *ban) TGetAllByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) ([]*model.Ban, error) {
+	query := `SELECT * FROM ban_history WHERE account_uuid = $1 ORDER BY created_at DESC`
+	return tGetAll[model.Ban](ctx, tx, query, accountUUID)
+}
+
+func (r *ban) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS ban_history (
+				id SERIAL PRIMARY KEY,
+				uuid VARCHAR(255) NOT NULL,
+				account_uuid VARCHAR(255) NOT NULL,
+				phone VARCHAR(255) NOT NULL,
+				code INTEGER NOT NULL,
+				reason TEXT NOT NULL DEFAULT '',
+				expires_at TIMESTAMP,
+				created_at TIMESTAMP NOT NULL,
+				lifted_at TIMESTAMP
+			);
+			CREATE INDEX IF NOT EXISTS ban_history_account_idx ON ban_history (account_uuid, created_at)`
+	return migrate(ctx, r.db, query)
+}
@@ -42,7 +42,7 @@ type TDeleter[E any] interface {
 }
 
 func tDelete(ctx context.Context, tx pgx.Tx, query string, args ...any) error {
-	cmd, err := tx.Exec(ctx, query, args)
+	cmd, err := tx.Exec(ctx, query, args...)
 	if err != nil {
 		return errs.New(err, errCode.Internal)
 	}
@@ -80,7 +80,7 @@ type TGetterAll[E any] interface {
 }
 
 func tGetAll[T any](ctx context.Context, tx pgx.Tx, query string, args ...any) ([]*T, error) {
-	rows, err := tx.Query(ctx, query, args)
+	rows, err := tx.Query(ctx, query, args...)
 	if err != nil {
 		return nil, errs.New(err, errCode.Internal)
 	}
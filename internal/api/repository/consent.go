@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+type Consent interface {
+	Migrater
+	TCreater[model.Consent]
+	TGetAllByPhone(ctx context.Context, tx pgx.Tx, accountUUID string, phone string) ([]*model.Consent, error)
+}
+
+type consent struct {
+	db *pgxpool.Pool
+}
+
+func NewConsent(db *pgxpool.Pool) Consent {
+	return &consent{db: db}
+}
+
+func (r *consent) TCreate(ctx context.Context, tx pgx.Tx, c *model.Consent) error {
+	c.UUID = uuid.New().String()
+	c.CreatedAt = time.Now().UTC()
+	query := `INSERT INTO consents (uuid, account_uuid, phone, action, source, evidence, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	id, err := tCreate(ctx, tx, query, c.UUID, c.AccountUUID, c.Phone, c.Action, c.Source, c.Evidence, c.CreatedAt)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	c.ID = id
+	return nil
+}
+
+func (r *consent) TGetAllByPhone(ctx context.Context, tx pgx.Tx, accountUUID string, phone string) ([]*model.Consent, error) {
+	query := `SELECT * FROM consents WHERE account_uuid = $1 AND phone = $2 ORDER BY created_at`
+	return tGetAll[model.Consent](ctx, tx, query, accountUUID, phone)
+}
+
+func (r *consent) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS consents (
+				id SERIAL PRIMARY KEY,
+				uuid VARCHAR(255) NOT NULL,
+				account_uuid VARCHAR(255) NOT NULL,
+				phone VARCHAR(255) NOT NULL,
+				action VARCHAR(32) NOT NULL,
+				source VARCHAR(255) NOT NULL,
+				evidence TEXT NOT NULL DEFAULT '',
+				created_at TIMESTAMP NOT NULL
+			);
+			CREATE INDEX IF NOT EXISTS consents_phone_idx ON consents (account_uuid, phone)`
+	return migrate(ctx, r.db, query)
+}
+
+type Suppression interface {
+	Migrater
+	TCreater[model.Suppression]
+	TDeleter[model.Suppression]
+	TGetByPhone(ctx context.Context, tx pgx.Tx, accountUUID string, phone string) (*model.Suppression, error)
+	TGetAllByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) ([]*model.Suppression, error)
+}
+
+type suppression struct {
+	db *pgxpool.Pool
+}
+
+func NewSuppression(db *pgxpool.Pool) Suppression {
+	return &suppression{db: db}
+}
+
+// TCreate keeps the original entry when the phone is already suppressed.
+func (r *suppression) TCreate(ctx context.Context, tx pgx.Tx, s *model.Suppression) error {
+	s.CreatedAt = time.Now().UTC()
+	query := `INSERT INTO suppressions (account_uuid, phone, reason, created_at) VALUES ($1, $2, $3, $4)
+				ON CONFLICT (account_uuid, phone) DO UPDATE SET phone = EXCLUDED.phone
+				RETURNING id`
+	id, err := tCreate(ctx, tx, query, s.AccountUUID, s.Phone, s.Reason, s.CreatedAt)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	s.ID = id
+	return nil
+}
+
+func (r *suppression) TDelete(ctx context.Context, tx pgx.Tx, s *model.Suppression) error {
+	query := `DELETE FROM suppressions WHERE id = $1`
+	return tDelete(ctx, tx, query, s.ID)
+}
+
+func (r *suppression) TGetByPhone(ctx context.Context, tx pgx.Tx, accountUUID string, phone string) (*model.Suppression, error) {
+	query := `SELECT * FROM suppressions WHERE account_uuid = $1 AND phone = $2`
+	return tGet[model.Suppression](ctx, tx, query, accountUUID, phone)
+}
+
+func (r *suppression) TGetAllByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) ([]*model.Suppression, error) {
+	query := `SELECT * FROM suppressions WHERE account_uuid = $1 ORDER BY created_at`
+	return tGetAll[model.Suppression](ctx, tx, query, accountUUID)
+}
+
+func (r *suppression) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS suppressions (
+				id SERIAL PRIMARY KEY,
+				account_uuid VARCHAR(255) NOT NULL,
+				phone VARCHAR(255) NOT NULL,
+				reason VARCHAR(255) NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				UNIQUE (account_uuid, phone)
+			)`
+	return migrate(ctx, r.db, query)
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+type Conversation interface {
+	Migrater
+	TCreater[model.Conversation]
+	TUpdater[model.Conversation]
+	TGetterByUUID[model.Conversation]
+	TGetOpenByPhone(ctx context.Context, tx pgx.Tx, accountUUID string, phone string) (*model.Conversation, error)
+	TGetAllByAccount(ctx context.Context, tx pgx.Tx, accountUUID string, status string) ([]*model.Conversation, error)
+}
+
+type conversation struct {
+	db *pgxpool.Pool
+}
+
+func NewConversation(db *pgxpool.Pool) Conversation {
+	return &conversation{db: db}
+}
+
+func (r *conversation) TGetOpenByPhone(ctx context.Context, tx pgx.Tx, accountUUID string, phone string) (*model.Conversation, error) {
+	query := `SELECT * FROM conversations WHERE account_uuid = $1 AND phone = $2 AND status <> $3`
+	return tGet[model.Conversation](ctx, tx, query, accountUUID, phone, model.ConversationClosed)
+}
+
+func (r *conversation) TGetByUUID(ctx context.Context, tx pgx.Tx, uuid string) (*model.Conversation, error) {
+	query := `SELECT * FROM conversations WHERE uuid = $1`
+	return tGet[model.Conversation](ctx, tx, query, uuid)
+}
+
+// TGetAllByAccount lists the conversations of an account, optionally filtered by status.
+func (r *conversation) TGetAllByAccount(ctx context.Context, tx pgx.Tx, accountUUID string, status string) ([]*model.Conversation, error) {
+	query := `SELECT * FROM conversations WHERE account_uuid = $1 AND ($2 = '' OR status = $2) ORDER BY updated_at DESC`
+	return tGetAll[model.Conversation](ctx, tx, query, accountUUID, status)
+}
+
+func (r *conversation) TCreate(ctx context.Context, tx pgx.Tx, conv *model.Conversation) error {
+	conv.UUID = uuid.New().String()
+	conv.CreatedAt = time.Now().UTC()
+	conv.UpdatedAt = time.Now().UTC()
+	query := `INSERT INTO conversations (uuid, account_uuid, phone, status, agent_uuid, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	id, err := tCreate(ctx, tx, query, conv.UUID, conv.AccountUUID, conv.Phone, conv.Status, conv.AgentUUID, conv.CreatedAt, conv.UpdatedAt)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	conv.ID = id
+	return nil
+}
+
+func (r *conversation) TUpdate(ctx context.Context, tx pgx.Tx, conv *model.Conversation) error {
+	conv.UpdatedAt = time.Now().UTC()
+	query := `UPDATE conversations SET status = $2, agent_uuid = $3, updated_at = $4 WHERE id = $1`
+	return tUpdate(ctx, tx, query, conv.ID, conv.Status, conv.AgentUUID, conv.UpdatedAt)
+}
+
+func (r *conversation) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS conversations (
+				id SERIAL PRIMARY KEY,
+				uuid VARCHAR(255) NOT NULL UNIQUE,
+				account_uuid VARCHAR(255) NOT NULL,
+				phone VARCHAR(255) NOT NULL,
+				status VARCHAR(32) NOT NULL,
+				agent_uuid VARCHAR(255) NOT NULL DEFAULT '',
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL
+			);
+			CREATE UNIQUE INDEX IF NOT EXISTS conversations_open_idx ON conversations (account_uuid, phone) WHERE status <> 'closed'`
+	return migrate(ctx, r.db, query)
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+type FlowSession interface {
+	Migrater
+	TCreater[model.FlowSession]
+	TUpdater[model.FlowSession]
+	TDeleter[model.FlowSession]
+	TGetByPhone(ctx context.Context, tx pgx.Tx, accountUUID string, phone string) (*model.FlowSession, error)
+}
+
+type flowSession struct {
+	db *pgxpool.Pool
+}
+
+func NewFlowSession(db *pgxpool.Pool) FlowSession {
+	return &flowSession{db: db}
+}
+
+func (r *flowSession) TGetByPhone(ctx context.Context, tx pgx.Tx, accountUUID string, phone string) (*model.FlowSession, error) {
+	query := `SELECT * FROM flow_sessions WHERE account_uuid = $1 AND phone = $2 AND expires_at > $3`
+	return tGet[model.FlowSession](ctx, tx, query, accountUUID, phone, time.Now().UTC())
+}
+
+// TCreate replaces any expired session left for the same account and phone.
+func (r *flowSession) TCreate(ctx context.Context, tx pgx.Tx, session *model.FlowSession) error {
+	session.UUID = uuid.New().String()
+	session.CreatedAt = time.Now().UTC()
+	session.UpdatedAt = time.Now().UTC()
+	query := `INSERT INTO flow_sessions (uuid, account_uuid, phone, flow, step, data, expires_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+				ON CONFLICT (account_uuid, phone) DO UPDATE SET uuid = $1, flow = $4, step = $5, data = $6, expires_at = $7, created_at = $8, updated_at = $9
+				RETURNING id`
+	id, err := tCreate(ctx, tx, query, session.UUID, session.AccountUUID, session.Phone, session.Flow, session.Step, session.Data, session.ExpiresAt, session.CreatedAt, session.UpdatedAt)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	session.ID = id
+	return nil
+}
+
+func (r *flowSession) TUpdate(ctx context.Context, tx pgx.Tx, session *model.FlowSession) error {
+	session.UpdatedAt = time.Now().UTC()
+	query := `UPDATE flow_sessions SET flow = $2, step = $3, data = $4, expires_at = $5, updated_at = $6 WHERE id = $1`
+	return tUpdate(ctx, tx, query, session.ID, session.Flow, session.Step, session.Data, session.ExpiresAt, session.UpdatedAt)
+}
+
+func (r *flowSession) TDelete(ctx context.Context, tx pgx.Tx, session *model.FlowSession) error {
+	query := `DELETE FROM flow_sessions WHERE id = $1`
+	return tDelete(ctx, tx, query, session.ID)
+}
+
+func (r *flowSession) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS flow_sessions (
+				id SERIAL PRIMARY KEY,
+				uuid VARCHAR(255) NOT NULL,
+				account_uuid VARCHAR(255) NOT NULL,
+				phone VARCHAR(255) NOT NULL,
+				flow VARCHAR(255) NOT NULL,
+				step VARCHAR(255) NOT NULL,
+				data JSONB NOT NULL DEFAULT '{}',
+				expires_at TIMESTAMP NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL,
+				UNIQUE (account_uuid, phone)
+			)`
+	return migrate(ctx, r.db, query)
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+type AccountLease interface {
+	Migrater
+	TCreater[model.AccountLease]
+	TDeleter[model.AccountLease]
+	TGetByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) (*model.AccountLease, error)
+	TRenew(ctx context.Context, tx pgx.Tx, nodeID string) error
+}
+
+type accountLease struct {
+	db *pgxpool.Pool
+}
+
+func NewAccountLease(db *pgxpool.Pool) AccountLease {
+	return &accountLease{db: db}
+}
+
+// TCreate takes over the lease of the account, replacing the one left by a previous owner.
+func (r *accountLease) TCreate(ctx context.Context, tx pgx.Tx, l *model.AccountLease) error {
+	l.AcquiredAt = time.Now().UTC()
+	l.RenewedAt = l.AcquiredAt
+	query := `INSERT INTO account_leases (account_uuid, node_id, acquired_at, renewed_at) VALUES ($1, $2, $3, $4)
+				ON CONFLICT (account_uuid) DO UPDATE SET node_id = EXCLUDED.node_id, acquired_at = EXCLUDED.acquired_at, renewed_at = EXCLUDED.renewed_at`
+	_, err := tx.Exec(ctx, query, l.AccountUUID, l.NodeID, l.AcquiredAt, l.RenewedAt)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+func (r *accountLease) TDelete(ctx context.Context, tx pgx.Tx, l *model.AccountLease) error {
+	query := `DELETE FROM account_leases WHERE account_uuid = $1 AND node_id = $2`
+	return tDelete(ctx, tx, query, l.AccountUUID, l.NodeID)
+}
+
+func (r *accountLease) TGetByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) (*model.AccountLease, error) {
+	query := `SELECT * FROM account_leases WHERE account_uuid = $1`
+	return tGet[model.AccountLease](ctx, tx, query, accountUUID)
+}
+
+func (r *accountLease) TRenew(ctx context.Context, tx pgx.Tx, nodeID string) error {
+	query := `UPDATE account_leases SET renewed_at = $2 WHERE node_id = $1`
+	_, err := tx.Exec(ctx, query, nodeID, time.Now().UTC())
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+func (r *accountLease) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS account_leases (
+				account_uuid VARCHAR(255) PRIMARY KEY,
+				node_id VARCHAR(255) NOT NULL,
+				acquired_at TIMESTAMP NOT NULL,
+				renewed_at TIMESTAMP NOT NULL
+			);
+			CREATE INDEX IF NOT EXISTS account_leases_node_idx ON account_leases (node_id)`
+	return migrate(ctx, r.db, query)
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+type Node interface {
+	Migrater
+	TCreater[model.Node]
+	TGetByID(ctx context.Context, tx pgx.Tx, id string) (*model.Node, error)
+	TGetAllAlive(ctx context.Context, tx pgx.Tx, since time.Time) ([]*model.Node, error)
+	THeartbeat(ctx context.Context, tx pgx.Tx, id string) error
+}
+
+type node struct {
+	db *pgxpool.Pool
+}
+
+func NewNode(db *pgxpool.Pool) Node {
+	return &node{db: db}
+}
+
+// TCreate registers the node, replacing the entry of a previous run with the same id.
+func (r *node) TCreate(ctx context.Context, tx pgx.Tx, n *model.Node) error {
+	n.StartedAt = time.Now().UTC()
+	n.HeartbeatAt = n.StartedAt
+	query := `INSERT INTO nodes (id, address, started_at, heartbeat_at) VALUES ($1, $2, $3, $4)
+				ON CONFLICT (id) DO UPDATE SET address = EXCLUDED.address, started_at = EXCLUDED.started_at, heartbeat_at = EXCLUDED.heartbeat_at`
+	_, err := tx.Exec(ctx, query, n.ID, n.Address, n.StartedAt, n.HeartbeatAt)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+func (r *node) TGetByID(ctx context.Context, tx pgx.Tx, id string) (*model.Node, error) {
+	query := `SELECT * FROM nodes WHERE id = $1`
+	return tGet[model.Node](ctx, tx, query, id)
+}
+
+func (r *node) TGetAllAlive(ctx context.Context, tx pgx.Tx, since time.Time) ([]*model.Node, error) {
+	query := `SELECT * FROM nodes WHERE heartbeat_at > $1 ORDER BY id`
+	return tGetAll[model.Node](ctx, tx, query, since)
+}
+
+func (r *node) THeartbeat(ctx context.Context, tx pgx.Tx, id string) error {
+	query := `UPDATE nodes SET heartbeat_at = $2 WHERE id = $1`
+	return tUpdate(ctx, tx, query, id, time.Now().UTC())
+}
+
+func (r *node) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS nodes (
+				id VARCHAR(255) PRIMARY KEY,
+				address VARCHAR(255) NOT NULL,
+				started_at TIMESTAMP NOT NULL,
+				heartbeat_at TIMESTAMP NOT NULL
+			)`
+	return migrate(ctx, r.db, query)
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+type Outbox interface {
+	Migrater
+	TCreater[model.OutboundMessage]
+	TUpdater[model.OutboundMessage]
+	TGetterByUUID[model.OutboundMessage]
+	TClaimDue(ctx context.Context, tx pgx.Tx, accountUUID string, limit int, lease time.Duration) ([]*model.OutboundMessage, error)
+	TGetDueAccounts(ctx context.Context, tx pgx.Tx) ([]string, error)
+	TCountSentSince(ctx context.Context, tx pgx.Tx, accountUUID string, since time.Time) (int64, error)
+}
+
+type outbox struct {
+	db *pgxpool.Pool
+}
+
+func NewOutbox(db *pgxpool.Pool) Outbox {
+	return &outbox{db: db}
+}
+
+func (r *outbox) TCreate(ctx context.Context, tx pgx.Tx, msg *model.OutboundMessage) error {
+	msg.UUID = uuid.New().String()
+	msg.Status = model.OutboxQueued
+	msg.CreatedAt = time.Now().UTC()
+	msg.UpdatedAt = time.Now().UTC()
+	msg.NextAttemptAt = msg.CreatedAt
+	query := `INSERT INTO outbound_messages (uuid, account_uuid, recipient, text, media, status, next_attempt_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`
+	id, err := tCreate(ctx, tx, query, msg.UUID, msg.AccountUUID, msg.Recipient, msg.Text, msg.Media, msg.Status, msg.NextAttemptAt, msg.CreatedAt, msg.UpdatedAt)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	msg.ID = id
+	return nil
+}
+
+func (r *outbox) TUpdate(ctx context.Context, tx pgx.Tx, msg *model.OutboundMessage) error {
+	msg.UpdatedAt = time.Now().UTC()
+	query := `UPDATE outbound_messages SET status = $2, attempts = $3, last_error = $4, message_id = $5, next_attempt_at = $6, sent_at = $7, updated_at = $8 WHERE id = $1`
+	return tUpdate(ctx, tx, query, msg.ID, msg.Status, msg.Attempts, msg.LastError, msg.MessageID, msg.NextAttemptAt, msg.SentAt, msg.UpdatedAt)
+}
+
+func (r *outbox) TGetByUUID(ctx context.Context, tx pgx.Tx, uuid string) (*model.OutboundMessage, error) {
+	query := `SELECT * FROM outbound_messages WHERE uuid = $1`
+	return tGet[model.OutboundMessage](ctx, tx, query, uuid)
+}
+
+// TClaimDue marks the next due messages of the account as sending for the duration of lease.
+// Messages left sending by a crashed worker become due again once their lease expires.
+func (r *outbox) TClaimDue(ctx context.Context, tx pgx.Tx, accountUUID string, limit int, lease time.Duration) ([]*model.OutboundMessage, error) {
+	now := time.Now().UTC()
+	query := `UPDATE outbound_messages SET status = $3, next_attempt_at = $4 WHERE id IN (
+				SELECT id FROM outbound_messages WHERE account_uuid = $1 AND status IN ($5, $3) AND next_attempt_at <= $2
+				ORDER BY id LIMIT $6 FOR UPDATE SKIP LOCKED
+			) RETURNING *`
+	return tGetAll[model.OutboundMessage](ctx, tx, query, accountUUID, now, model.OutboxSending, now.Add(lease), model.OutboxQueued, limit)
+}
+
+func (r *outbox) TGetDueAccounts(ctx context.Context, tx pgx.Tx) ([]string, error) {
+	query := `SELECT DISTINCT account_uuid FROM outbound_messages WHERE status IN ($1, $2) AND next_attempt_at <= $3`
+	rows, err := tx.Query(ctx, query, model.OutboxQueued, model.OutboxSending, time.Now().UTC())
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	accounts, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return accounts, nil
+}
+
+func (r *outbox) TCountSentSince(ctx context.Context, tx pgx.Tx, accountUUID string, since time.Time) (int64, error) {
+	query := `SELECT COUNT(*) FROM outbound_messages WHERE account_uuid = $1 AND status = $2 AND sent_at >= $3`
+	return tCount(ctx, tx, query, accountUUID, model.OutboxSent, since)
+}
+
+func (r *outbox) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS outbound_messages (
+				id SERIAL PRIMARY KEY,
+				uuid VARCHAR(255) NOT NULL UNIQUE,
+				account_uuid VARCHAR(255) NOT NULL,
+				recipient VARCHAR(255) NOT NULL,
+				text TEXT NOT NULL,
+				media BYTEA,
+				status VARCHAR(32) NOT NULL,
+				attempts INTEGER NOT NULL DEFAULT 0,
+				last_error TEXT NOT NULL DEFAULT '',
+				message_id VARCHAR(255) NOT NULL DEFAULT '',
+				next_attempt_at TIMESTAMP NOT NULL,
+				sent_at TIMESTAMP,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL
+			);
+			CREATE INDEX IF NOT EXISTS outbound_messages_due_idx ON outbound_messages (account_uuid, next_attempt_at) WHERE status IN ('queued', 'sending')`
+	return migrate(ctx, r.db, query)
+}
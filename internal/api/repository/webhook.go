@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+type Webhook interface {
+	Migrater
+	TCreater[model.Webhook]
+	TDeleter[model.Webhook]
+	TGetterByUUID[model.Webhook]
+	TGetAllByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) ([]*model.Webhook, error)
+}
+
+type webhook struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhook(db *pgxpool.Pool) Webhook {
+	return &webhook{db: db}
+}
+
+func (r *webhook) TCreate(ctx context.Context, tx pgx.Tx, w *model.Webhook) error {
+	w.UUID = uuid.New().String()
+	w.CreatedAt = time.Now().UTC()
+	w.UpdatedAt = time.Now().UTC()
+	query := `INSERT INTO webhooks (uuid, account_uuid, url, secret, active, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+	id, err := tCreate(ctx, tx, query, w.UUID, w.AccountUUID, w.URL, w.Secret, w.Active, w.CreatedAt, w.UpdatedAt)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	w.ID = id
+	return nil
+}
+
+func (r *webhook) TDelete(ctx context.Context, tx pgx.Tx, w *model.Webhook) error {
+	query := `DELETE FROM webhooks WHERE id = $1`
+	return tDelete(ctx, tx, query, w.ID)
+}
+
+func (r *webhook) TGetByUUID(ctx context.Context, tx pgx.Tx, uuid string) (*model.Webhook, error) {
+	query := `SELECT * FROM webhooks WHERE uuid = $1`
+	return tGet[model.Webhook](ctx, tx, query, uuid)
+}
+
+func (r *webhook) TGetAllByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) ([]*model.Webhook, error) {
+	query := `SELECT * FROM webhooks WHERE account_uuid = $1 ORDER BY created_at`
+	return tGetAll[model.Webhook](ctx, tx, query, accountUUID)
+}
+
+func (r *webhook) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS webhooks (
+				id SERIAL PRIMARY KEY,
+				uuid VARCHAR(255) NOT NULL UNIQUE,
+				account_uuid VARCHAR(255) NOT NULL,
+				url TEXT NOT NULL,
+				secret VARCHAR(255) NOT NULL,
+				active BOOLEAN DEFAULT TRUE,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL
+			)`
+	return migrate(ctx, r.db, query)
+}
+
+type WebhookDelivery interface {
+	Migrater
+	TCreater[model.WebhookDelivery]
+	TUpdater[model.WebhookDelivery]
+	TDeleter[model.WebhookDelivery]
+	TClaimDue(ctx context.Context, tx pgx.Tx, limit int, lease time.Duration) ([]*model.WebhookDelivery, error)
+}
+
+type webhookDelivery struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookDelivery(db *pgxpool.Pool) WebhookDelivery {
+	return &webhookDelivery{db: db}
+}
+
+func (r *webhookDelivery) TCreate(ctx context.Context, tx pgx.Tx, d *model.WebhookDelivery) error {
+	if d.UUID == "" {
+		d.UUID = uuid.New().String()
+	}
+	d.CreatedAt = time.Now().UTC()
+	d.UpdatedAt = time.Now().UTC()
+	if d.NextAttemptAt.IsZero() {
+		d.NextAttemptAt = d.CreatedAt
+	}
+	query := `INSERT INTO webhook_deliveries (uuid, webhook_uuid, account_uuid, event, payload, attempts, last_error, next_attempt_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id`
+	id, err := tCreate(ctx, tx, query, d.UUID, d.WebhookUUID, d.AccountUUID, d.Event, d.Payload, d.Attempts, d.LastError, d.NextAttemptAt, d.CreatedAt, d.UpdatedAt)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	d.ID = id
+	return nil
+}
+
+func (r *webhookDelivery) TUpdate(ctx context.Context, tx pgx.Tx, d *model.WebhookDelivery) error {
+	d.UpdatedAt = time.Now().UTC()
+	query := `UPDATE webhook_deliveries SET attempts = $2, last_error = $3, next_attempt_at = $4, updated_at = $5 WHERE id = $1`
+	return tUpdate(ctx, tx, query, d.ID, d.Attempts, d.LastError, d.NextAttemptAt, d.UpdatedAt)
+}
+
+func (r *webhookDelivery) TDelete(ctx context.Context, tx pgx.Tx, d *model.WebhookDelivery) error {
+	query := `DELETE FROM webhook_deliveries WHERE id = $1`
+	return tDelete(ctx, tx, query, d.ID)
+}
+
+// TClaimDue returns the deliveries whose next attempt is due and postpones them by lease,
+// so that other workers skip them while they are being sent.
+func (r *webhookDelivery) TClaimDue(ctx context.Context, tx pgx.Tx, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	now := time.Now().UTC()
+	query := `UPDATE webhook_deliveries SET next_attempt_at = $2 WHERE id IN (
+				SELECT id FROM webhook_deliveries WHERE next_attempt_at <= $1 ORDER BY next_attempt_at LIMIT $3 FOR UPDATE SKIP LOCKED
+			) RETURNING *`
+	return tGetAll[model.WebhookDelivery](ctx, tx, query, now, now.Add(lease), limit)
+}
+
+func (r *webhookDelivery) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS webhook_deliveries (
+				id SERIAL PRIMARY KEY,
+				uuid VARCHAR(255) NOT NULL UNIQUE,
+				webhook_uuid VARCHAR(255) NOT NULL,
+				account_uuid VARCHAR(255) NOT NULL,
+				event VARCHAR(255) NOT NULL,
+				payload JSONB NOT NULL,
+				attempts INTEGER NOT NULL DEFAULT 0,
+				last_error TEXT NOT NULL DEFAULT '',
+				next_attempt_at TIMESTAMP NOT NULL,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL
+			);
+			CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at)`
+	return migrate(ctx, r.db, query)
+}
+
+type WebhookDeadLetter interface {
+	Migrater
+	TCreater[model.WebhookDeadLetter]
+	TDeleter[model.WebhookDeadLetter]
+	TGetterByUUID[model.WebhookDeadLetter]
+	TGetAllByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) ([]*model.WebhookDeadLetter, error)
+}
+
+type webhookDeadLetter struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookDeadLetter(db *pgxpool.Pool) WebhookDeadLetter {
+	return &webhookDeadLetter{db: db}
+}
+
+func (r *webhookDeadLetter) TCreate(ctx context.Context, tx pgx.Tx, d *model.WebhookDeadLetter) error {
+	d.CreatedAt = time.Now().UTC()
+	query := `INSERT INTO webhook_dead_letters (uuid, webhook_uuid, account_uuid, event, payload, attempts, last_error, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
+	id, err := tCreate(ctx, tx, query, d.UUID, d.WebhookUUID, d.AccountUUID, d.Event, d.Payload, d.Attempts, d.LastError, d.CreatedAt)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	d.ID = id
+	return nil
+}
+
+func (r *webhookDeadLetter) TDelete(ctx context.Context, tx pgx.Tx, d *model.WebhookDeadLetter) error {
+	query := `DELETE FROM webhook_dead_letters WHERE id = $1`
+	return tDelete(ctx, tx, query, d.ID)
+}
+
+func (r *webhookDeadLetter) TGetByUUID(ctx context.Context, tx pgx.Tx, uuid string) (*model.WebhookDeadLetter, error) {
+	query := `SELECT * FROM webhook_dead_letters WHERE uuid = $1`
+	return tGet[model.WebhookDeadLetter](ctx, tx, query, uuid)
+}
+
+func (r *webhookDeadLetter) TGetAllByAccount(ctx context.Context, tx pgx.Tx, accountUUID string) ([]*model.WebhookDeadLetter, error) {
+	query := `SELECT * FROM webhook_dead_letters WHERE account_uuid = $1 ORDER BY created_at`
+	return tGetAll[model.WebhookDeadLetter](ctx, tx, query, accountUUID)
+}
+
+func (r *webhookDeadLetter) Migrate(ctx context.Context) error {
+	query := `CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+				id SERIAL PRIMARY KEY,
+				uuid VARCHAR(255) NOT NULL UNIQUE,
+				webhook_uuid VARCHAR(255) NOT NULL,
+				account_uuid VARCHAR(255) NOT NULL,
+				event VARCHAR(255) NOT NULL,
+				payload JSONB NOT NULL,
+				attempts INTEGER NOT NULL,
+				last_error TEXT NOT NULL DEFAULT '',
+				created_at TIMESTAMP NOT NULL
+			)`
+	return migrate(ctx, r.db, query)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
 	"time"
 )
 
@@ -17,6 +18,8 @@ type WhatsApp interface {
 	TGetterByUUID[model.WhatsApp]
 	TGetterAll[model.WhatsApp]
 	TGetByAccountId(ctx context.Context, tx pgx.Tx, accountUUID string) (*model.WhatsApp, error)
+	TGetAllActive(ctx context.Context, tx pgx.Tx) ([]*model.WhatsApp, error)
+	TResetConnected(ctx context.Context, tx pgx.Tx, nodeID string, renewedSince time.Time) (int64, error)
 }
 
 type whatsApp struct {
@@ -36,10 +39,10 @@ func (r *whatsApp) TCreate(ctx context.Context, tx pgx.Tx, whats *model.WhatsApp
 	whats.UUID = uuid.New().String()
 	whats.CreatedAt = time.Now().UTC()
 	whats.UpdatedAt = time.Now().UTC()
-	query := `INSERT INTO whatsapps (uuid, account_uuid, phone, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
-	id, err := tCreate(ctx, tx, query, whats.UUID, whats.AccountUUID, whats.Phone, whats.CreatedAt, whats.UpdatedAt)
+	query := `INSERT INTO whatsapps (uuid, account_uuid, phone, active, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	id, err := tCreate(ctx, tx, query, whats.UUID, whats.AccountUUID, whats.Phone, whats.Active, whats.CreatedAt, whats.UpdatedAt)
 	if err != nil {
-		return errs.Wrap(err, "")
+		return errCode.Wrap(err)
 	}
 	whats.ID = id
 	return nil
@@ -55,10 +58,27 @@ func (r *whatsApp) TGetAll(ctx context.Context, tx pgx.Tx) ([]*model.WhatsApp, e
 	return tGetAll[model.WhatsApp](ctx, tx, query)
 }
 
+func (r *whatsApp) TGetAllActive(ctx context.Context, tx pgx.Tx) ([]*model.WhatsApp, error) {
+	query := `SELECT * FROM whatsapps WHERE active = true ORDER BY created_at DESC`
+	return tGetAll[model.WhatsApp](ctx, tx, query)
+}
+
+// TResetConnected marks as disconnected the accounts without a live lease held
+// by another node and returns how many were connected.
+func (r *whatsApp) TResetConnected(ctx context.Context, tx pgx.Tx, nodeID string, renewedSince time.Time) (int64, error) {
+	query := `UPDATE whatsapps SET connected = false, updated_at = $1 WHERE connected = true
+				AND account_uuid NOT IN (SELECT account_uuid FROM account_leases WHERE node_id <> $2 AND renewed_at > $3)`
+	cmd, err := tx.Exec(ctx, query, time.Now().UTC(), nodeID, renewedSince)
+	if err != nil {
+		return 0, errs.New(err, errCode.Internal)
+	}
+	return cmd.RowsAffected(), nil
+}
+
 func (r *whatsApp) TUpdate(ctx context.Context, tx pgx.Tx, whats *model.WhatsApp) error {
 	whats.UpdatedAt = time.Now().UTC()
-	query := `UPDATE whatsapps SET connected = $2, active = $3, banned = $4, updated_at = $5 WHERE id = $1`
-	return tUpdate(ctx, tx, query, whats.ID, whats.Connected, whats.Active, whats.Banned, whats.UpdatedAt)
+	query := `UPDATE whatsapps SET connected = $2, active = $3, banned = $4, ban_code = $5, ban_reason = $6, ban_expires_at = $7, updated_at = $8 WHERE id = $1`
+	return tUpdate(ctx, tx, query, whats.ID, whats.Connected, whats.Active, whats.Banned, whats.BanCode, whats.BanReason, whats.BanExpiresAt, whats.UpdatedAt)
 }
 
 func (r *whatsApp) TDelete(ctx context.Context, tx pgx.Tx, whats *model.WhatsApp) error {
@@ -77,6 +97,9 @@ func (r *whatsApp) Migrate(ctx context.Context) error {
 				banned BOOLEAN DEFAULT FALSE, 
 				created_at TIMESTAMP NOT NULL, 
 				updated_at TIMESTAMP NOT NULL
-			)`
+			);
+			ALTER TABLE whatsapps ADD COLUMN IF NOT EXISTS ban_code INTEGER NOT NULL DEFAULT 0;
+			ALTER TABLE whatsapps ADD COLUMN IF NOT EXISTS ban_reason TEXT NOT NULL DEFAULT '';
+			ALTER TABLE whatsapps ADD COLUMN IF NOT EXISTS ban_expires_at TIMESTAMP`
 	return migrate(ctx, r.db, query)
 }
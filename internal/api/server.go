@@ -2,14 +2,29 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"net"
+	"os"
+	"os/signal"
 	"qrpay-wpp/configs"
+	"qrpay-wpp/internal/api/bus"
+	"qrpay-wpp/internal/api/certs"
+	"qrpay-wpp/internal/api/flow"
+	"qrpay-wpp/internal/api/gateway"
+	"qrpay-wpp/internal/api/interceptor"
+	"qrpay-wpp/internal/api/repository"
+	"qrpay-wpp/internal/api/service"
 	"qrpay-wpp/internal/api/system"
+	"syscall"
+	"time"
 )
 
 type Server struct {
@@ -17,10 +32,14 @@ type Server struct {
 
 	db      *pgxpool.Pool
 	context context.Context
+	cancel  context.CancelFunc
+	bus     bus.Bus
 
 	repos    *repositories
 	handlers *handlers
 	services *services
+
+	hooks map[string]flow.Hook
 }
 
 func (s *Server) createDatabaseIfNotExists(err error) error {
@@ -72,6 +91,8 @@ func (s *Server) startDatabase() error {
 }
 
 func (s *Server) initializeAPI() error {
+	// Background workers run until the server shuts down
+	s.context, s.cancel = context.WithCancel(s.context)
 
 	// Create Repositories
 	err := s.createRepositories()
@@ -85,13 +106,90 @@ func (s *Server) initializeAPI() error {
 	}
 
 	// Create Services
-	s.createServices(wppSystem)
+	err = s.createServices(wppSystem)
+	if err != nil {
+		return err
+	}
+
+	// Clear the connected flags left by a previous run
+	err = s.services.recon.Reset(s.context)
+	if err != nil {
+		return err
+	}
+
+	// Announce this node before it claims its share of the accounts
+	_, err = s.services.cluster.Check(s.context)
+	if err != nil {
+		return err
+	}
+
+	// Start background workers
+	go s.services.webhook.Start(s.context)
+	go s.services.outbox.Start(s.context)
+	go s.services.recon.Start(s.context)
+	go s.services.conv.Start(s.context)
+
+	// Restore paired sessions, then keep them balanced among the nodes
+	sc := configs.Get().Sessions
+	stagger := time.Duration(sc.RestoreStagger) * time.Millisecond
+	go func() {
+		err := s.services.wpp.Restore(s.context, sc.RestoreConcurrency, stagger)
+		if err != nil {
+			fmt.Printf("Unable to restore sessions: %v\n", err)
+		}
+		interval := time.Duration(configs.Get().Cluster.BalanceInterval) * time.Second
+		if interval <= 0 {
+			interval = 15 * time.Second
+		}
+		s.services.wpp.Start(s.context, interval, sc.RestoreConcurrency, stagger)
+	}()
 
 	// Create Handlers
 	s.createHandlers()
 
-	// Create a new gRPC server
-	grpcServer := grpc.NewServer()
+	// Load the certificates of the server, reloading them as they are renewed
+	var options []grpc.ServerOption
+	var forwardCreds credentials.TransportCredentials
+	var peers interceptor.PeerAuthenticator
+	var store *certs.Store
+	if tc := configs.Get().TLS; tc.Enabled {
+		store, err = certs.New(tc, configs.Get().Server.KeysPath)
+		if err != nil {
+			return err
+		}
+		go store.Start(s.context)
+		options = append(options, grpc.Creds(credentials.NewTLS(store.ServerConfig())))
+		forwardCreds = credentials.NewTLS(store.ClientConfig())
+		peers = store
+	}
+
+	// Create a new gRPC server, translating handler errors into statuses, authenticating
+	// callers, validating requests and forwarding account-scoped requests to the node owning the account
+	forwarder := interceptor.NewForwarder(s.services.cluster, forwardCreds,
+		"/proto.WhatsAppService/Connect",
+		"/proto.WhatsAppService/Message",
+		"/proto.WhatsAppService/Reply",
+		"/proto.WhatsAppService/QR",
+		"/proto.WhatsAppService/Status",
+	)
+	translator := interceptor.NewErrors()
+	validator := interceptor.NewValidator()
+	unary := []grpc.UnaryServerInterceptor{translator.Unary()}
+	stream := []grpc.StreamServerInterceptor{translator.Stream()}
+	if configs.Get().Auth.Enabled {
+		auth := interceptor.NewAuth(s.services.auth, peers, "proto.AdminService").Public(healthpb.Health_ServiceDesc.ServiceName)
+		unary = append(unary, auth.Unary())
+		stream = append(stream, auth.Stream())
+	} else {
+		fmt.Printf("Authentication is disabled, every caller may act on any account\n")
+	}
+	unary = append(unary, validator.Unary(), forwarder.Unary())
+	stream = append(stream, validator.Stream(), forwarder.Stream())
+	options = append(options,
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+	grpcServer := grpc.NewServer(options...)
 
 	// Register the Services
 	s.registerServices(grpcServer)
@@ -103,24 +201,120 @@ func (s *Server) initializeAPI() error {
 		return fmt.Errorf("failed to listen: %v", err)
 	}
 
-	// Start the gRPC server
-	fmt.Printf("gRPC server listening at %s\n", listener.Addr())
-	if err := grpcServer.Serve(listener); err != nil {
-		return fmt.Errorf("failed to start server: %v", err)
+	// Serve the HTTP/JSON gateway, calling this node like any other client
+	var gw *gateway.Gateway
+	if gc := configs.Get().Gateway; gc.Enabled {
+		creds := insecure.NewCredentials()
+		var tlsConfig *tls.Config
+		if store != nil {
+			creds = credentials.NewTLS(store.ClientConfig())
+			tlsConfig = store.GatewayConfig()
+		}
+		gw, err = gateway.New(s.context, fmt.Sprintf("localhost:%d", configs.Get().Server.Port), creds, gc.Port, tlsConfig, s.services.pairing)
+		if err != nil {
+			return err
+		}
+		go func() {
+			err := gw.Serve()
+			if err != nil {
+				fmt.Printf("HTTP gateway stopped: %v\n", err)
+			}
+		}()
 	}
 
+	// Start the gRPC server, until it fails or the process is asked to stop
+	fmt.Printf("gRPC server listening at %s\n", listener.Addr())
+	served := make(chan error, 1)
+	go func() {
+		served <- grpcServer.Serve(listener)
+	}()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	select {
+	case sig := <-signals:
+		fmt.Printf("Received %s, shutting down\n", sig)
+		s.shutdown(grpcServer, gw)
+	case err = <-served:
+		s.shutdown(grpcServer, gw)
+		if err != nil {
+			return fmt.Errorf("failed to start server: %v", err)
+		}
+	}
 	return nil
 }
 
+// shutdown stops accepting requests, sends the queued messages and disconnects
+// the sessions of this node before closing its connections. Each step is bounded
+// by the configured timeouts so that a stuck one does not hold the others.
+func (s *Server) shutdown(grpcServer *grpc.Server, gw *gateway.Gateway) {
+	sc := configs.Get().Shutdown
+	grace := time.Duration(sc.GracePeriod) * time.Second
+	if grace <= 0 {
+		grace = 10 * time.Second
+	}
+	drain := time.Duration(sc.DrainTimeout) * time.Second
+	if drain <= 0 {
+		drain = 20 * time.Second
+	}
+
+	// Stop accepting requests, closing the streams still open after the grace period
+	ctx, cancel := context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	if gw != nil {
+		err := gw.Shutdown(ctx)
+		if err != nil {
+			fmt.Printf("HTTP gateway did not stop gracefully: %v\n", err)
+		}
+	}
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+
+	// Send the queued messages while the sessions are still connected
+	ctx, cancel = context.WithTimeout(context.Background(), drain)
+	defer cancel()
+	err := s.services.outbox.Drain(ctx)
+	if err != nil {
+		fmt.Printf("Unable to send every queued message: %v\n", err)
+	}
+
+	// Stop the background workers, then the sessions
+	s.cancel()
+	ctx, cancel = context.WithTimeout(context.Background(), grace)
+	defer cancel()
+	err = s.services.wpp.Shutdown(ctx)
+	if err != nil {
+		fmt.Printf("Unable to release the sessions: %v\n", err)
+	}
+	s.bus.Close()
+	s.db.Close()
+	fmt.Printf("Server stopped\n")
+}
+
 func New(settingsPath string) *Server {
 	return &Server{
 		settingsPath: settingsPath,
 		repos:        &repositories{},
 		handlers:     &handlers{},
 		services:     &services{},
+		hooks:        map[string]flow.Hook{},
 	}
 }
 
+// RegisterFlowHook makes a backend hook available to the flow definitions
+// under the given name. It must be called before Start.
+func (s *Server) RegisterFlowHook(name string, hook flow.Hook) {
+	s.hooks[name] = hook
+}
+
 func (s *Server) Start() error {
 	err := configs.Load(s.settingsPath)
 	if err != nil {
@@ -136,3 +330,31 @@ func (s *Server) Start() error {
 	}
 	return nil
 }
+
+// CreateAPIKey creates an API key without starting the API, which bootstraps
+// the first admin key. It returns the key, which cannot be retrieved again.
+func (s *Server) CreateAPIKey(name string, accounts []string, admin bool) (string, error) {
+	err := configs.Load(s.settingsPath)
+	if err != nil {
+		return "", fmt.Errorf("could not load config: %w", err)
+	}
+	err = s.startDatabase()
+	if err != nil {
+		return "", fmt.Errorf("could not start database: %w", err)
+	}
+	defer s.db.Close()
+	repo := repository.NewAPIKey(s.db)
+	err = repo.Migrate(s.context)
+	if err != nil {
+		return "", fmt.Errorf("unable to migrate api key repository: %v", err)
+	}
+	auth, err := service.NewAuth(s.db, repo, configs.Get().Auth, nil)
+	if err != nil {
+		return "", err
+	}
+	_, key, err := auth.CreateKey(s.context, name, accounts, admin)
+	if err != nil {
+		return "", fmt.Errorf("unable to create api key: %v", err)
+	}
+	return key, nil
+}
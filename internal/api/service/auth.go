@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/configs"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/api/repository"
+	"qrpay-wpp/internal/errCode"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// apiKeyPrefix starts every API key, telling them apart from JWTs.
+	apiKeyPrefix = "wpp_"
+
+	defaultAccountsClaim = "accounts"
+	defaultAdminScope    = "admin"
+	defaultKeyCacheTTL   = 30 * time.Second
+
+	// apiKeyTouchInterval limits how often the last use of a key is written.
+	apiKeyTouchInterval = time.Minute
+)
+
+// Auth authenticates the callers of the API from an API key, a JWT or the token
+// of a pairing link.
+type Auth interface {
+	Authenticate(ctx context.Context, token string) (*model.Principal, error)
+	CreateKey(ctx context.Context, name string, accounts []string, admin bool) (*model.APIKey, string, error)
+	RevokeKey(ctx context.Context, uuid string) error
+	ListKeys(ctx context.Context) ([]*model.APIKey, error)
+}
+
+type cachedKey struct {
+	key       *model.APIKey
+	expiresAt time.Time
+}
+
+type auth struct {
+	pool    *pgxpool.Pool
+	repo    repository.APIKey
+	config  configs.Auth
+	jwks    *jwks
+	pairing Pairing
+	ttl     time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedKey
+}
+
+// NewAuth authenticates pairing tokens with pairing, unless it is nil.
+func NewAuth(pool *pgxpool.Pool, repo repository.APIKey, config configs.Auth, pairing Pairing) (Auth, error) {
+	s := &auth{
+		pool:    pool,
+		repo:    repo,
+		config:  config,
+		pairing: pairing,
+		ttl:     time.Duration(config.KeyCacheTTL) * time.Second,
+		cache:   map[string]cachedKey{},
+	}
+	if s.config.AccountsClaim == "" {
+		s.config.AccountsClaim = defaultAccountsClaim
+	}
+	if s.config.AdminScope == "" {
+		s.config.AdminScope = defaultAdminScope
+	}
+	if s.ttl <= 0 {
+		s.ttl = defaultKeyCacheTTL
+	}
+	if config.JWKSPath != "" {
+		j, err := newJWKS(config.JWKSPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load jwks: %v", err)
+		}
+		s.jwks = j
+	}
+	return s, nil
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func unauthenticated(message string) error {
+	return errs.New(errors.New(message), errCode.Unauthenticated)
+}
+
+func (s *auth) Authenticate(ctx context.Context, token string) (*model.Principal, error) {
+	if token == "" {
+		return nil, unauthenticated("missing credentials")
+	}
+	if strings.HasPrefix(token, apiKeyPrefix) {
+		return s.authenticateKey(ctx, token)
+	}
+	if strings.HasPrefix(token, pairingTokenPrefix) && s.pairing != nil {
+		accountUUID, _, err := s.pairing.Verify(token)
+		if err != nil {
+			return nil, errCode.Wrap(err)
+		}
+		return &model.Principal{
+			Subject:  "pairing:" + accountUUID,
+			Method:   "pairing",
+			Accounts: []string{accountUUID},
+			Methods:  PairingMethods,
+		}, nil
+	}
+	return s.authenticateJWT(token)
+}
+
+func (s *auth) authenticateKey(ctx context.Context, token string) (*model.Principal, error) {
+	hash := hashKey(token)
+	key, err := s.lookup(ctx, hash)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	if key == nil || key.RevokedAt != nil {
+		return nil, unauthenticated("invalid api key")
+	}
+	if key.LastUsedAt == nil || time.Since(*key.LastUsedAt) > apiKeyTouchInterval {
+		s.touch(ctx, key)
+	}
+	return &model.Principal{
+		Subject:  key.UUID,
+		Method:   "api_key",
+		Accounts: key.Accounts,
+		Admin:    key.Admin,
+	}, nil
+}
+
+// lookup returns the key with the given hash, cached for a while so that every
+// request does not hit the database. Revocations made on other nodes take effect
+// within the cache TTL. Cached keys are shared and must not be modified.
+func (s *auth) lookup(ctx context.Context, hash string) (*model.APIKey, error) {
+	s.mu.Lock()
+	cached, ok := s.cache[hash]
+	s.mu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.key, nil
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	key, _ := s.repo.TGetByHash(ctx, tx, hash)
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	if key != nil {
+		s.mu.Lock()
+		s.cache[hash] = cachedKey{key: key, expiresAt: time.Now().Add(s.ttl)}
+		s.mu.Unlock()
+	}
+	return key, nil
+}
+
+// touch records the use of the key. Failures only cost the accuracy of the last use.
+func (s *auth) touch(ctx context.Context, key *model.APIKey) {
+	now := time.Now().UTC()
+	touched := *key
+	touched.LastUsedAt = &now
+	s.mu.Lock()
+	if cached, ok := s.cache[key.Hash]; ok {
+		s.cache[key.Hash] = cachedKey{key: &touched, expiresAt: cached.expiresAt}
+	}
+	s.mu.Unlock()
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return
+	}
+	defer tx.Rollback(ctx)
+	err = s.repo.TUpdate(ctx, tx, &touched)
+	if err != nil {
+		return
+	}
+	_ = tx.Commit(ctx)
+}
+
+func (s *auth) authenticateJWT(token string) (*model.Principal, error) {
+	if s.jwks == nil {
+		return nil, unauthenticated("jwt authentication is not configured")
+	}
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if s.config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(s.config.Issuer))
+	}
+	if s.config.Audience != "" {
+		options = append(options, jwt.WithAudience(s.config.Audience))
+	}
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return s.jwks.key(kid)
+	}, options...)
+	if err != nil {
+		return nil, unauthenticated("invalid token: " + err.Error())
+	}
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil, unauthenticated("token has no expiration")
+	}
+	subject, _ := claims.GetSubject()
+	return &model.Principal{
+		Subject:  subject,
+		Method:   "jwt",
+		Accounts: claimStrings(claims[s.config.AccountsClaim]),
+		Admin:    contains(claimStrings(claims["scope"]), s.config.AdminScope),
+	}, nil
+}
+
+// claimStrings reads a claim holding either a list of strings or a space
+// separated string, as the scope claim does.
+func claimStrings(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateKey stores a new API key and returns it along with the key itself, which
+// is not stored and cannot be retrieved again.
+func (s *auth) CreateKey(ctx context.Context, name string, accounts []string, admin bool) (*model.APIKey, string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, "", errs.New(err, errCode.Internal)
+	}
+	token := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, "", errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	key := &model.APIKey{
+		Name:     name,
+		Prefix:   token[:len(apiKeyPrefix)+6],
+		Hash:     hashKey(token),
+		Accounts: accounts,
+		Admin:    admin,
+	}
+	err = s.repo.TCreate(ctx, tx, key)
+	if err != nil {
+		return nil, "", errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, "", errs.New(err, errCode.Internal)
+	}
+	return key, token, nil
+}
+
+func (s *auth) RevokeKey(ctx context.Context, uuid string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	key, _ := s.repo.TGetByUUID(ctx, tx, uuid)
+	if key == nil {
+		return errs.New(fmt.Errorf("api key not found"), errCode.NotFound)
+	}
+	if key.RevokedAt == nil {
+		now := time.Now().UTC()
+		key.RevokedAt = &now
+		err = s.repo.TUpdate(ctx, tx, key)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	s.mu.Lock()
+	delete(s.cache, key.Hash)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *auth) ListKeys(ctx context.Context) ([]*model.APIKey, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	keys, _ := s.repo.TGetAll(ctx, tx)
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return keys, nil
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/api/repository"
+	"qrpay-wpp/internal/errCode"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Advisory lock namespaces, used as the first key of the two-key advisory locks.
+const (
+	nodeLockNamespace    = 0x6e6f6465
+	accountLockNamespace = 0x77707061
+)
+
+// Cluster decides which node owns the session of each account. Ownership is a
+// session-level advisory lock held on a connection dedicated to this node, so
+// the locks of a node that dies are released with its connection and other
+// nodes can take its accounts over.
+type Cluster interface {
+	NodeID() string
+	Acquire(ctx context.Context, accountUUID string) (bool, error)
+	Release(ctx context.Context, accountUUID string) error
+	Owns(accountUUID string) bool
+	Owned() []string
+	Nodes(ctx context.Context) (int, error)
+	Check(ctx context.Context) ([]string, error)
+	Route(ctx context.Context, accountUUID string) (string, error)
+	Close(ctx context.Context) error
+}
+
+type cluster struct {
+	pool    *pgxpool.Pool
+	repo    repository.AccountLease
+	nodes   repository.Node
+	nodeID  string
+	address string
+	ttl     time.Duration
+
+	mu    sync.Mutex
+	conn  *pgx.Conn
+	owned map[string]bool
+}
+
+func NewCluster(pool *pgxpool.Pool, repo repository.AccountLease, nodes repository.Node, nodeID string, address string, ttl time.Duration) Cluster {
+	return &cluster{
+		pool:    pool,
+		repo:    repo,
+		nodes:   nodes,
+		nodeID:  nodeID,
+		address: address,
+		ttl:     ttl,
+		owned:   map[string]bool{},
+	}
+}
+
+func (s *cluster) NodeID() string {
+	return s.nodeID
+}
+
+// connect takes a connection out of the pool to hold the locks of this node,
+// announcing the node through its node lock and registry entry. It fails when
+// another process holds the node lock, as with a duplicated node id. Callers must hold s.mu.
+func (s *cluster) connect(ctx context.Context) error {
+	if s.conn != nil {
+		return nil
+	}
+	c, err := s.pool.Acquire(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	conn := c.Hijack()
+	var locked bool
+	err = conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1, hashtext($2))`, nodeLockNamespace, s.nodeID).Scan(&locked)
+	if err != nil {
+		conn.Close(ctx)
+		return errs.New(err, errCode.Internal)
+	}
+	if !locked {
+		conn.Close(ctx)
+		return errs.New(fmt.Errorf("node id %s already in use by another process", s.nodeID), errCode.AlreadyExists)
+	}
+	s.conn = conn
+	return s.lease(ctx, func(tx pgx.Tx) error {
+		return s.nodes.TCreate(ctx, tx, &model.Node{ID: s.nodeID, Address: s.address})
+	})
+}
+
+func (s *cluster) Acquire(ctx context.Context, accountUUID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.owned[accountUUID] {
+		return true, nil
+	}
+	err := s.connect(ctx)
+	if err != nil {
+		return false, errCode.Wrap(err)
+	}
+	var locked bool
+	err = s.conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1, hashtext($2))`, accountLockNamespace, accountUUID).Scan(&locked)
+	if err != nil {
+		return false, errs.New(err, errCode.Internal)
+	}
+	if !locked {
+		return false, nil
+	}
+	s.owned[accountUUID] = true
+
+	err = s.lease(ctx, func(tx pgx.Tx) error {
+		return s.repo.TCreate(ctx, tx, &model.AccountLease{AccountUUID: accountUUID, NodeID: s.nodeID})
+	})
+	if err != nil {
+		return true, errCode.Wrap(err)
+	}
+	return true, nil
+}
+
+func (s *cluster) Release(ctx context.Context, accountUUID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.owned[accountUUID] {
+		return nil
+	}
+	delete(s.owned, accountUUID)
+	if s.conn != nil && !s.conn.IsClosed() {
+		_, err := s.conn.Exec(ctx, `SELECT pg_advisory_unlock($1, hashtext($2))`, accountLockNamespace, accountUUID)
+		if err != nil {
+			return errs.New(err, errCode.Internal)
+		}
+	}
+	// The lease may already be gone or taken over, which is not an error.
+	err := s.lease(ctx, func(tx pgx.Tx) error {
+		_ = s.repo.TDelete(ctx, tx, &model.AccountLease{AccountUUID: accountUUID, NodeID: s.nodeID})
+		return nil
+	})
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	return nil
+}
+
+func (s *cluster) lease(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+	err = fn(tx)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+func (s *cluster) Owns(accountUUID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.owned[accountUUID]
+}
+
+// Owned returns the accounts owned by this node, sorted.
+func (s *cluster) Owned() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	owned := make([]string, 0, len(s.owned))
+	for accountUUID := range s.owned {
+		owned = append(owned, accountUUID)
+	}
+	sort.Strings(owned)
+	return owned
+}
+
+// Nodes counts the live nodes from the node locks they hold.
+func (s *cluster) Nodes(ctx context.Context) (int, error) {
+	query := `SELECT count(*) FROM pg_locks
+				WHERE locktype = 'advisory' AND granted AND classid = $1
+				AND database = (SELECT oid FROM pg_database WHERE datname = current_database())`
+	var n int
+	err := s.pool.QueryRow(ctx, query, nodeLockNamespace).Scan(&n)
+	if err != nil {
+		return 0, errs.New(err, errCode.Internal)
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n, nil
+}
+
+// Check verifies that the lock connection is alive and renews the leases of
+// this node. When the connection was lost so were its locks, and the accounts
+// this node owned are returned so their sessions can be stopped.
+func (s *cluster) Check(ctx context.Context) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		err := s.conn.Ping(ctx)
+		if err != nil {
+			s.conn.Close(ctx)
+			s.conn = nil
+		}
+	}
+	if s.conn == nil {
+		lost := make([]string, 0, len(s.owned))
+		for accountUUID := range s.owned {
+			lost = append(lost, accountUUID)
+		}
+		s.owned = map[string]bool{}
+		err := s.connect(ctx)
+		if err != nil {
+			return lost, errCode.Wrap(err)
+		}
+		if len(lost) > 0 {
+			fmt.Printf("Lost ownership of %d accounts\n", len(lost))
+		}
+		return lost, nil
+	}
+	err := s.lease(ctx, func(tx pgx.Tx) error {
+		err := s.nodes.THeartbeat(ctx, tx, s.nodeID)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+		return s.repo.TRenew(ctx, tx, s.nodeID)
+	})
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return nil, nil
+}
+
+// Close releases the accounts of this node and closes its lock connection, so
+// that the other nodes take them over without waiting for the leases to expire.
+func (s *cluster) Close(ctx context.Context) error {
+	for _, accountUUID := range s.Owned() {
+		err := s.Release(ctx, accountUUID)
+		if err != nil {
+			fmt.Printf("Unable to release %s: %v\n", accountUUID, err)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close(ctx)
+	s.conn = nil
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+// Route returns the address of the live node owning the account, or an empty
+// address when the account is owned by this node or by none.
+func (s *cluster) Route(ctx context.Context, accountUUID string) (string, error) {
+	if s.Owns(accountUUID) {
+		return "", nil
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return "", errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	lease, _ := s.repo.TGetByAccount(ctx, tx, accountUUID)
+	if lease == nil || lease.NodeID == s.nodeID {
+		return "", nil
+	}
+	node, _ := s.nodes.TGetByID(ctx, tx, lease.NodeID)
+	if node == nil || node.HeartbeatAt.Before(time.Now().UTC().Add(-s.ttl)) {
+		return "", nil
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return "", errs.New(err, errCode.Internal)
+	}
+	return node.Address, nil
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/api/repository"
+	"qrpay-wpp/internal/common"
+	"qrpay-wpp/internal/errCode"
+	"strings"
+)
+
+const consentSourceKeyword = "whatsapp_keyword"
+
+type Consent interface {
+	IsOptOutKeyword(text string) bool
+	OptIn(ctx context.Context, accountUUID string, phone string, source string, evidence string) (*model.Consent, error)
+	OptOut(ctx context.Context, accountUUID string, phone string, source string, evidence string) (*model.Consent, error)
+	OptOutByKeyword(ctx context.Context, accountUUID string, phone string, text string) (string, error)
+	Check(ctx context.Context, accountUUID string, phone string) error
+	Get(ctx context.Context, accountUUID string, phone string) (*model.Suppression, []*model.Consent, error)
+	ListSuppressed(ctx context.Context, accountUUID string) ([]*model.Suppression, error)
+}
+
+type consent struct {
+	pool        *pgxpool.Pool
+	repo        repository.Consent
+	suppression repository.Suppression
+	keywords    []string
+	reply       string
+}
+
+func NewConsent(pool *pgxpool.Pool, repo repository.Consent, suppression repository.Suppression, keywords []string, reply string) Consent {
+	return &consent{
+		pool:        pool,
+		repo:        repo,
+		suppression: suppression,
+		keywords:    keywords,
+		reply:       reply,
+	}
+}
+
+func (s *consent) IsOptOutKeyword(text string) bool {
+	text = strings.TrimSpace(text)
+	for _, keyword := range s.keywords {
+		if strings.EqualFold(keyword, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *consent) OptIn(ctx context.Context, accountUUID string, phone string, source string, evidence string) (*model.Consent, error) {
+	phone = common.SanitizePhone(phone)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	sup, err := s.suppression.TGetByPhone(ctx, tx, accountUUID, phone)
+	if err != nil && !errCode.Is(err, errCode.NotChanged) {
+		return nil, errCode.Wrap(err)
+	}
+	if sup != nil {
+		err = s.suppression.TDelete(ctx, tx, sup)
+		if err != nil {
+			return nil, errCode.Wrap(err)
+		}
+	}
+	entry := &model.Consent{
+		AccountUUID: accountUUID,
+		Phone:       phone,
+		Action:      model.ConsentOptIn,
+		Source:      source,
+		Evidence:    evidence,
+	}
+	err = s.repo.TCreate(ctx, tx, entry)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return entry, nil
+}
+
+func (s *consent) OptOut(ctx context.Context, accountUUID string, phone string, source string, evidence string) (*model.Consent, error) {
+	phone = common.SanitizePhone(phone)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	sup := &model.Suppression{
+		AccountUUID: accountUUID,
+		Phone:       phone,
+		Reason:      source,
+	}
+	err = s.suppression.TCreate(ctx, tx, sup)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	entry := &model.Consent{
+		AccountUUID: accountUUID,
+		Phone:       phone,
+		Action:      model.ConsentOptOut,
+		Source:      source,
+		Evidence:    evidence,
+	}
+	err = s.repo.TCreate(ctx, tx, entry)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return entry, nil
+}
+
+// OptOutByKeyword records an opt-out requested by the customer, keeping the message as evidence,
+// and returns the confirmation to be sent back.
+func (s *consent) OptOutByKeyword(ctx context.Context, accountUUID string, phone string, text string) (string, error) {
+	_, err := s.OptOut(ctx, accountUUID, phone, consentSourceKeyword, text)
+	if err != nil {
+		return "", errCode.Wrap(err)
+	}
+	return s.reply, nil
+}
+
+// Check returns an errCode.Suppressed error when the phone must not be messaged.
+func (s *consent) Check(ctx context.Context, accountUUID string, phone string) error {
+	phone = common.SanitizePhone(phone)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	// A failed lookup must not let messages through to opted-out recipients
+	sup, err := s.suppression.TGetByPhone(ctx, tx, accountUUID, phone)
+	if err != nil && !errCode.Is(err, errCode.NotChanged) {
+		return errCode.Wrap(err)
+	}
+	if sup != nil {
+		return errs.New(errors.New("recipient opted out"), errCode.Suppressed)
+	}
+	return nil
+}
+
+func (s *consent) Get(ctx context.Context, accountUUID string, phone string) (*model.Suppression, []*model.Consent, error) {
+	phone = common.SanitizePhone(phone)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	sup, err := s.suppression.TGetByPhone(ctx, tx, accountUUID, phone)
+	if err != nil && !errCode.Is(err, errCode.NotChanged) {
+		return nil, nil, errCode.Wrap(err)
+	}
+	history, err := s.repo.TGetAllByPhone(ctx, tx, accountUUID, phone)
+	if err != nil {
+		return nil, nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, nil, errs.New(err, errCode.Internal)
+	}
+	return sup, history, nil
+}
+
+func (s *consent) ListSuppressed(ctx context.Context, accountUUID string) ([]*model.Suppression, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	sups, err := s.suppression.TGetAllByAccount(ctx, tx, accountUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return sups, nil
+}
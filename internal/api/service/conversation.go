@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/bus"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/api/repository"
+	"qrpay-wpp/internal/common"
+	"qrpay-wpp/internal/errCode"
+	"sync"
+	"time"
+)
+
+// ConversationTopic is the bus topic of the inbound messages of assigned conversations.
+const ConversationTopic = "conversation.message"
+
+type ConversationMessage struct {
+	ConversationUUID string    `json:"conversation_uuid"`
+	AccountUUID      string    `json:"account_uuid"`
+	AgentUUID        string    `json:"agent_uuid"`
+	From             string    `json:"from"`
+	Text             string    `json:"text"`
+	ReceivedAt       time.Time `json:"received_at"`
+}
+
+type Conversation interface {
+	Track(ctx context.Context, accountUUID string, phone string) (*model.Conversation, error)
+	Handoff(ctx context.Context, accountUUID string, phone string) (*model.Conversation, error)
+	List(ctx context.Context, accountUUID string, status string) ([]*model.Conversation, error)
+	Assign(ctx context.Context, accountUUID string, conversationUUID string, agentUUID string) (*model.Conversation, error)
+	Release(ctx context.Context, accountUUID string, conversationUUID string) (*model.Conversation, error)
+	Close(ctx context.Context, accountUUID string, conversationUUID string) (*model.Conversation, error)
+	Deliver(conv *model.Conversation, text string)
+	Subscribe(accountUUID string, agentUUID string) (<-chan *ConversationMessage, func())
+	Start(ctx context.Context)
+}
+
+type conversation struct {
+	pool *pgxpool.Pool
+	repo repository.Conversation
+	bus  bus.Bus
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan *ConversationMessage]struct{}
+}
+
+func NewConversation(pool *pgxpool.Pool, repo repository.Conversation, bus bus.Bus) Conversation {
+	return &conversation{
+		pool:        pool,
+		repo:        repo,
+		bus:         bus,
+		subscribers: map[string]map[chan *ConversationMessage]struct{}{},
+	}
+}
+
+func (s *conversation) getOrCreate(ctx context.Context, tx pgx.Tx, accountUUID string, phone string) (*model.Conversation, error) {
+	phone = common.SanitizePhone(phone)
+	conv, _ := s.repo.TGetOpenByPhone(ctx, tx, accountUUID, phone)
+	if conv != nil {
+		return conv, nil
+	}
+	conv = &model.Conversation{
+		AccountUUID: accountUUID,
+		Phone:       phone,
+		Status:      model.ConversationBot,
+	}
+	err := s.repo.TCreate(ctx, tx, conv)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return conv, nil
+}
+
+// Track returns the open conversation with the phone, opening a new one owned by the bot if needed.
+func (s *conversation) Track(ctx context.Context, accountUUID string, phone string) (*model.Conversation, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	conv, err := s.getOrCreate(ctx, tx, accountUUID, phone)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return conv, nil
+}
+
+func (s *conversation) Handoff(ctx context.Context, accountUUID string, phone string) (*model.Conversation, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	conv, err := s.getOrCreate(ctx, tx, accountUUID, phone)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	if conv.Status == model.ConversationBot {
+		conv.Status = model.ConversationWaiting
+		err = s.repo.TUpdate(ctx, tx, conv)
+		if err != nil {
+			return nil, errCode.Wrap(err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return conv, nil
+}
+
+func (s *conversation) List(ctx context.Context, accountUUID string, status string) ([]*model.Conversation, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	convs, err := s.repo.TGetAllByAccount(ctx, tx, accountUUID, status)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return convs, nil
+}
+
+// transition loads the conversation of the account and applies change to it,
+// rejecting the change when the conversation is not in one of the from statuses.
+func (s *conversation) transition(ctx context.Context, accountUUID string, conversationUUID string, from []string, change func(*model.Conversation)) (*model.Conversation, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	conv, err := s.repo.TGetByUUID(ctx, tx, conversationUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	if conv.AccountUUID != accountUUID {
+		return nil, errs.New(errors.New("conversation not found"), errCode.NotFound)
+	}
+	allowed := false
+	for _, status := range from {
+		if conv.Status == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, errs.New(errors.New("conversation is "+conv.Status), errCode.NotChanged)
+	}
+	change(conv)
+	err = s.repo.TUpdate(ctx, tx, conv)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return conv, nil
+}
+
+func (s *conversation) Assign(ctx context.Context, accountUUID string, conversationUUID string, agentUUID string) (*model.Conversation, error) {
+	from := []string{model.ConversationBot, model.ConversationWaiting, model.ConversationAssigned}
+	return s.transition(ctx, accountUUID, conversationUUID, from, func(conv *model.Conversation) {
+		conv.Status = model.ConversationAssigned
+		conv.AgentUUID = agentUUID
+	})
+}
+
+func (s *conversation) Release(ctx context.Context, accountUUID string, conversationUUID string) (*model.Conversation, error) {
+	from := []string{model.ConversationAssigned}
+	return s.transition(ctx, accountUUID, conversationUUID, from, func(conv *model.Conversation) {
+		conv.Status = model.ConversationWaiting
+		conv.AgentUUID = ""
+	})
+}
+
+func (s *conversation) Close(ctx context.Context, accountUUID string, conversationUUID string) (*model.Conversation, error) {
+	from := []string{model.ConversationBot, model.ConversationWaiting, model.ConversationAssigned}
+	return s.transition(ctx, accountUUID, conversationUUID, from, func(conv *model.Conversation) {
+		conv.Status = model.ConversationClosed
+	})
+}
+
+func subscriberKey(accountUUID string, agentUUID string) string {
+	return accountUUID + "/" + agentUUID
+}
+
+// Deliver publishes an inbound message of an assigned conversation on the bus,
+// so the agent streams receive it whichever node they are connected to.
+func (s *conversation) Deliver(conv *model.Conversation, text string) {
+	if conv.Status != model.ConversationAssigned {
+		return
+	}
+	msg, err := bus.NewMessage(ConversationTopic, "message", conv.AccountUUID, &ConversationMessage{
+		ConversationUUID: conv.UUID,
+		AccountUUID:      conv.AccountUUID,
+		AgentUUID:        conv.AgentUUID,
+		From:             conv.Phone,
+		Text:             text,
+		ReceivedAt:       time.Now().UTC(),
+	})
+	if err == nil {
+		err = s.bus.Publish(context.Background(), msg)
+	}
+	if err != nil {
+		fmt.Printf("Conversation delivery failed: %v\n", err)
+	}
+}
+
+// Start forwards the conversation messages of the bus to the local agent
+// streams until ctx is done. Slow subscribers miss messages instead of blocking.
+func (s *conversation) Start(ctx context.Context) {
+	messages, unsubscribe := s.bus.Subscribe(ConversationTopic)
+	defer unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case m, ok := <-messages:
+			if !ok {
+				return
+			}
+			msg := &ConversationMessage{}
+			err := m.Decode(msg)
+			if err != nil {
+				continue
+			}
+			s.dispatch(msg)
+		}
+	}
+}
+
+func (s *conversation) dispatch(msg *ConversationMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subscribers[subscriberKey(msg.AccountUUID, msg.AgentUUID)] {
+		select {
+		case ch <- msg:
+		default:
+		}
+	}
+}
+
+func (s *conversation) Subscribe(accountUUID string, agentUUID string) (<-chan *ConversationMessage, func()) {
+	key := subscriberKey(accountUUID, agentUUID)
+	ch := make(chan *ConversationMessage, 64)
+	s.mu.Lock()
+	if s.subscribers[key] == nil {
+		s.subscribers[key] = map[chan *ConversationMessage]struct{}{}
+	}
+	s.subscribers[key][ch] = struct{}{}
+	s.mu.Unlock()
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers[key], ch)
+		if len(s.subscribers[key]) == 0 {
+			delete(s.subscribers, key)
+		}
+	}
+}
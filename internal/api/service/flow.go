@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5/pgxpool"
+	flowEngine "qrpay-wpp/internal/api/flow"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/api/repository"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+type Flow interface {
+	Handle(ctx context.Context, accountUUID string, phone string, text string) (*flowEngine.Result, error)
+}
+
+type flow struct {
+	pool   *pgxpool.Pool
+	repo   repository.FlowSession
+	engine *flowEngine.Engine
+	ttl    time.Duration
+}
+
+func NewFlow(pool *pgxpool.Pool, repo repository.FlowSession, engine *flowEngine.Engine, ttl time.Duration) Flow {
+	return &flow{
+		pool:   pool,
+		repo:   repo,
+		engine: engine,
+		ttl:    ttl,
+	}
+}
+
+func (s *flow) Handle(ctx context.Context, accountUUID string, phone string, text string) (*flowEngine.Result, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	session, _ := s.repo.TGetByPhone(ctx, tx, accountUUID, phone)
+	isNew := session == nil
+	if isNew {
+		session = &model.FlowSession{
+			AccountUUID: accountUUID,
+			Phone:       phone,
+		}
+	}
+
+	res, err := s.engine.Handle(ctx, session, text)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	if !res.Handled {
+		return res, nil
+	}
+
+	session.ExpiresAt = time.Now().UTC().Add(s.ttl)
+	switch {
+	case res.Done && !isNew:
+		err = s.repo.TDelete(ctx, tx, session)
+	case res.Done:
+	case isNew:
+		err = s.repo.TCreate(ctx, tx, session)
+	default:
+		err = s.repo.TUpdate(ctx, tx, session)
+	}
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return res, nil
+}
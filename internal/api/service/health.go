@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/repository"
+	server "qrpay-wpp/internal/api/system"
+	"qrpay-wpp/internal/errCode"
+	"strings"
+	"time"
+)
+
+// AccountHealthPrefix starts the health service names of the accounts, as in
+// "account/<accountUUID>".
+const AccountHealthPrefix = "account/"
+
+// healthTimeout bounds the database pings of a health check.
+const healthTimeout = 2 * time.Second
+
+type Health interface {
+	Check(ctx context.Context, service string) (bool, error)
+}
+
+type health struct {
+	pool     *pgxpool.Pool
+	repo     repository.WhatsApp
+	system   server.WhatsAppSystem
+	services map[string]bool
+}
+
+// NewHealth reports the node as a whole under the empty name and the given
+// services. It serves when both the database and the session store answer.
+func NewHealth(pool *pgxpool.Pool, repo repository.WhatsApp, system server.WhatsAppSystem, services ...string) Health {
+	h := &health{pool: pool, repo: repo, system: system, services: map[string]bool{"": true}}
+	for _, s := range services {
+		h.services[s] = true
+	}
+	return h
+}
+
+// Check returns whether the service is serving. Accounts serve while their
+// session is connected, wherever in the cluster it runs.
+func (h *health) Check(ctx context.Context, service string) (bool, error) {
+	if h.services[service] {
+		return h.ping(ctx), nil
+	}
+	accountUUID, ok := strings.CutPrefix(service, AccountHealthPrefix)
+	if !ok {
+		return false, errs.New(errors.New("unknown service"), errCode.NotFound)
+	}
+	return h.account(ctx, accountUUID)
+}
+
+func (h *health) ping(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+	if err := h.pool.Ping(ctx); err != nil {
+		return false
+	}
+	if err := h.system.Ping(ctx); err != nil {
+		return false
+	}
+	return true
+}
+
+// account prefers the live state of the sessions of this node, and falls back
+// to the connected flag kept by the node owning the account.
+func (h *health) account(ctx context.Context, accountUUID string) (bool, error) {
+	if state := h.system.State(accountUUID); state != server.StateNew {
+		return state == server.StateConnected, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		return false, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	whats, _ := h.repo.TGetByAccountId(ctx, tx, accountUUID)
+	if whats == nil {
+		return false, errs.New(errors.New("unknown service"), errCode.NotFound)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, errs.New(err, errCode.Internal)
+	}
+	return whats.Active && whats.Connected, nil
+}
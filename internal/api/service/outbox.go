@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	mrand "math/rand"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/api/repository"
+	server "qrpay-wpp/internal/api/system"
+	"qrpay-wpp/internal/errCode"
+	"sync"
+	"time"
+)
+
+const (
+	outboxBatchSize   = 10
+	outboxLease       = 2 * time.Minute
+	outboxMaxBackoff  = 10 * time.Minute
+	outboxPollingRate = time.Second
+	outboxIdleTimeout = time.Minute
+	// outboxReconnectDelay defers the sends of accounts whose session is reconnecting,
+	// for up to outboxReconnectTimeout after the message was queued.
+	outboxReconnectDelay   = 15 * time.Second
+	outboxReconnectTimeout = 30 * time.Minute
+	outboxMaxAttempts      = 5
+)
+
+type Outbox interface {
+	Admit(ctx context.Context, accountUUID string) error
+	Enqueue(ctx context.Context, tx pgx.Tx, accountUUID string, to string, text string, media []byte) (*model.OutboundMessage, error)
+	Notify(accountUUID string)
+	Get(ctx context.Context, accountUUID string, messageUUID string) (*model.OutboundMessage, error)
+	Start(ctx context.Context)
+	Drain(ctx context.Context) error
+}
+
+type OutboxOptions struct {
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+type outbox struct {
+	pool    *pgxpool.Pool
+	repo    repository.Outbox
+	system  server.WhatsAppSystem
+	warmup  Warmup
+	cluster Cluster
+	options OutboxOptions
+
+	mu      sync.Mutex
+	ctx     context.Context
+	cancel  context.CancelFunc
+	workers map[string]chan struct{}
+	busy    int
+	wg      sync.WaitGroup
+}
+
+func NewOutbox(pool *pgxpool.Pool, repo repository.Outbox, system server.WhatsAppSystem, warmup Warmup, cluster Cluster, options OutboxOptions) Outbox {
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = outboxMaxAttempts
+	}
+	return &outbox{
+		pool:    pool,
+		repo:    repo,
+		system:  system,
+		warmup:  warmup,
+		cluster: cluster,
+		options: options,
+		workers: map[string]chan struct{}{},
+	}
+}
+
+// Admit rejects the messages of accounts that used up their daily cap or warm-up
+// allowance, so that callers learn they are limited rather than find their
+// messages deferred.
+func (s *outbox) Admit(ctx context.Context, accountUUID string) error {
+	err := s.system.Quota(accountUUID)
+	if err != nil {
+		return err
+	}
+	return s.warmup.Check(ctx, accountUUID)
+}
+
+// Enqueue writes the message to the outbox within the caller's transaction.
+// Callers should Notify the account once the transaction is committed.
+func (s *outbox) Enqueue(ctx context.Context, tx pgx.Tx, accountUUID string, to string, text string, media []byte) (*model.OutboundMessage, error) {
+	msg := &model.OutboundMessage{
+		AccountUUID: accountUUID,
+		Recipient:   to,
+		Text:        text,
+		Media:       media,
+	}
+	err := s.repo.TCreate(ctx, tx, msg)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	return msg, nil
+}
+
+func (s *outbox) Get(ctx context.Context, accountUUID string, messageUUID string) (*model.OutboundMessage, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	msg, err := s.repo.TGetByUUID(ctx, tx, messageUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	if msg.AccountUUID != accountUUID {
+		return nil, errs.New(fmt.Errorf("message not found"), errCode.NotFound)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return msg, nil
+}
+
+// Notify wakes the worker of the account, starting one if needed. Messages of
+// accounts owned by other nodes are left to the workers of their owner.
+func (s *outbox) Notify(accountUUID string) {
+	if !s.cluster.Owns(accountUUID) {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx == nil || s.ctx.Err() != nil {
+		return
+	}
+	wake, ok := s.workers[accountUUID]
+	if !ok {
+		wake = make(chan struct{}, 1)
+		s.workers[accountUUID] = wake
+		s.wg.Add(1)
+		go s.work(accountUUID, wake)
+	}
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start polls for accounts with due messages, such as retries and messages
+// left behind by a previous process, until ctx is done.
+func (s *outbox) Start(ctx context.Context) {
+	s.mu.Lock()
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	ctx = s.ctx
+	s.mu.Unlock()
+
+	ticker := time.NewTicker(outboxPollingRate)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			accounts, err := s.dueAccounts(ctx)
+			if err != nil {
+				fmt.Printf("Outbox polling failed: %v\n", err)
+				continue
+			}
+			for _, accountUUID := range accounts {
+				s.Notify(accountUUID)
+			}
+		}
+	}
+}
+
+// Drain keeps sending the due messages of the accounts owned by this node until
+// none is left or ctx is done, then stops the workers. Messages not sent by then
+// stay queued for the next owner of their account.
+func (s *outbox) Drain(ctx context.Context) error {
+	s.mu.Lock()
+	started := s.ctx != nil
+	s.mu.Unlock()
+	if !started {
+		return nil
+	}
+	defer s.stop()
+
+	ticker := time.NewTicker(outboxPollingRate)
+	defer ticker.Stop()
+	for {
+		accounts, err := s.dueAccounts(ctx)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+		due := 0
+		for _, accountUUID := range accounts {
+			if s.cluster.Owns(accountUUID) {
+				due++
+				s.Notify(accountUUID)
+			}
+		}
+		s.mu.Lock()
+		busy := s.busy
+		s.mu.Unlock()
+		if due == 0 && busy == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// stop cancels the workers and waits for them to return.
+func (s *outbox) stop() {
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *outbox) dueAccounts(ctx context.Context) ([]string, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+	return s.repo.TGetDueAccounts(ctx, tx)
+}
+
+func (s *outbox) work(accountUUID string, wake chan struct{}) {
+	defer s.wg.Done()
+	idle := time.NewTimer(outboxIdleTimeout)
+	defer idle.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-wake:
+			s.mu.Lock()
+			s.busy++
+			s.mu.Unlock()
+			err := s.drain(s.ctx, accountUUID)
+			s.mu.Lock()
+			s.busy--
+			s.mu.Unlock()
+			if err != nil {
+				fmt.Printf("Outbox worker %s failed: %v\n", accountUUID, err)
+			}
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(outboxIdleTimeout)
+		case <-idle.C:
+			s.mu.Lock()
+			if len(wake) == 0 {
+				delete(s.workers, accountUUID)
+				s.mu.Unlock()
+				return
+			}
+			s.mu.Unlock()
+			idle.Reset(outboxIdleTimeout)
+		}
+	}
+}
+
+func (s *outbox) drain(ctx context.Context, accountUUID string) error {
+	for ctx.Err() == nil {
+		msgs, err := s.claim(ctx, accountUUID)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+		if len(msgs) == 0 {
+			return nil
+		}
+		for _, msg := range msgs {
+			err = s.process(ctx, msg)
+			if err != nil {
+				return errCode.Wrap(err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *outbox) claim(ctx context.Context, accountUUID string) ([]*model.OutboundMessage, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	msgs, err := s.repo.TClaimDue(ctx, tx, accountUUID, outboxBatchSize, outboxLease)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return msgs, nil
+}
+
+func (s *outbox) process(ctx context.Context, msg *model.OutboundMessage) error {
+	var messageID string
+	sendErr := s.warmup.Check(ctx, msg.AccountUUID)
+	if sendErr == nil {
+		messageID, sendErr = s.system.SendMessage(ctx, msg.AccountUUID, msg.Recipient, msg.Text, msg.Media)
+	}
+
+	now := time.Now().UTC()
+	delay, deferred := retryDelay(sendErr)
+	if !deferred && reconnecting(sendErr) && now.Sub(msg.CreatedAt) < outboxReconnectTimeout {
+		delay, deferred = outboxReconnectDelay, true
+	}
+	switch {
+	case sendErr == nil:
+		msg.Attempts++
+		msg.Status = model.OutboxSent
+		msg.MessageID = messageID
+		msg.LastError = ""
+		msg.SentAt = &now
+	case s.sessionLost(msg.AccountUUID, sendErr):
+		msg.Attempts++
+		msg.Status = model.OutboxFailed
+		msg.LastError = sendErr.Error()
+	case deferred:
+		// Deferred sends, such as rate limited ones, do not count as attempts.
+		msg.Status = model.OutboxQueued
+		msg.LastError = sendErr.Error()
+		msg.NextAttemptAt = now.Add(delay)
+	case msg.Attempts+1 >= s.options.MaxAttempts:
+		msg.Attempts++
+		msg.Status = model.OutboxFailed
+		msg.LastError = sendErr.Error()
+	default:
+		msg.Attempts++
+		msg.Status = model.OutboxQueued
+		msg.LastError = sendErr.Error()
+		msg.NextAttemptAt = now.Add(s.backoff(msg.Attempts))
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	err = s.repo.TUpdate(ctx, tx, msg)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+// reconnecting reports whether the send failed for want of a connected session,
+// which is deferred while the session reconnects so that the reconnection
+// backoff does not use up its attempts.
+func reconnecting(err error) bool {
+	st, ok := status.FromError(err)
+	return ok && (st.Code() == codes.Unavailable || st.Code() == codes.NotFound)
+}
+
+// sessionLost reports whether the send failed because the session of the account
+// is gone for good, logged out or banned without an expiry, which no retry fixes.
+func (s *outbox) sessionLost(accountUUID string, err error) bool {
+	if !reconnecting(err) {
+		return false
+	}
+	state := s.system.State(accountUUID)
+	return state == server.StateLoggedOut || state == server.StateBanned
+}
+
+// retryDelay returns the delay requested by a ResourceExhausted error, or by a
+// FailedPrecondition one such as a temporary ban, through its RetryInfo.
+func retryDelay(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+	if st.Code() != codes.ResourceExhausted && st.Code() != codes.FailedPrecondition {
+		return 0, false
+	}
+	delay := time.Second
+	found := false
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			found = true
+			if info.RetryDelay.AsDuration() > delay {
+				delay = info.RetryDelay.AsDuration()
+			}
+		}
+	}
+	return delay, found || st.Code() == codes.ResourceExhausted
+}
+
+func (s *outbox) backoff(attempts int) time.Duration {
+	delay := s.options.Backoff << (attempts - 1)
+	if delay <= 0 || delay > outboxMaxBackoff {
+		delay = outboxMaxBackoff
+	}
+	jitter := time.Duration(mrand.Int63n(int64(delay)/5 + 1))
+	return delay + jitter
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	errs "github.com/cristiancll/go-errors"
+	"qrpay-wpp/configs"
+	"qrpay-wpp/internal/errCode"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// pairingTokenPrefix starts every pairing token, telling them apart from API keys and JWTs.
+	pairingTokenPrefix = "pair_"
+
+	defaultPairingLinkTTL  = 15 * time.Minute
+	minPairingSecretLength = 32
+)
+
+// PairingMethods are the RPCs a pairing token may call: enough to connect the
+// account, follow its QR code and learn when it is paired.
+var PairingMethods = []string{
+	"/proto.WhatsAppService/Connect",
+	"/proto.WhatsAppService/QR",
+	"/proto.WhatsAppService/Status",
+}
+
+// Pairing signs the links of the pairing page. A link carries a token naming the
+// account and its expiry, signed so that it cannot be forged or extended.
+type Pairing interface {
+	Link(accountUUID string) (string, time.Time, error)
+	Verify(token string) (string, time.Time, error)
+}
+
+type pairing struct {
+	secret  []byte
+	ttl     time.Duration
+	baseURL string
+}
+
+// NewPairing signs links with the configured secret, which every node behind the
+// gateway must share, so it is required when the gateway serves the pairing page.
+// Otherwise a random secret is used and links are only valid on the node that
+// created them.
+func NewPairing(config configs.Pairing, required bool) (Pairing, error) {
+	s := &pairing{
+		secret:  []byte(config.Secret),
+		ttl:     time.Duration(config.LinkTTL) * time.Second,
+		baseURL: strings.TrimSuffix(config.BaseURL, "/"),
+	}
+	if len(s.secret) > 0 && len(s.secret) < minPairingSecretLength {
+		return nil, fmt.Errorf("pairing secret must be at least %d characters", minPairingSecretLength)
+	}
+	if len(s.secret) == 0 && required {
+		return nil, errors.New("a pairing secret shared by every node is required to serve the pairing page, set pairing.secret or disable the gateway")
+	}
+	if len(s.secret) == 0 {
+		s.secret = make([]byte, 32)
+		_, err := rand.Read(s.secret)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("No pairing secret configured, pairing links are only valid on this node\n")
+	}
+	if s.ttl <= 0 {
+		s.ttl = defaultPairingLinkTTL
+	}
+	return s, nil
+}
+
+func (s *pairing) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Link returns the URL of the pairing page of the account and its expiry.
+func (s *pairing) Link(accountUUID string) (string, time.Time, error) {
+	expiresAt := time.Now().Add(s.ttl).UTC().Truncate(time.Second)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(accountUUID + "." + strconv.FormatInt(expiresAt.Unix(), 10)))
+	token := pairingTokenPrefix + payload + "." + s.sign(payload)
+	return s.baseURL + "/pair/" + token, expiresAt, nil
+}
+
+// Verify returns the account of a valid pairing token and its expiry.
+func (s *pairing) Verify(token string) (string, time.Time, error) {
+	invalid := errs.New(errors.New("invalid pairing link"), errCode.Unauthenticated)
+	payload, signature, ok := strings.Cut(strings.TrimPrefix(token, pairingTokenPrefix), ".")
+	if !ok || !strings.HasPrefix(token, pairingTokenPrefix) {
+		return "", time.Time{}, invalid
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return "", time.Time{}, invalid
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", time.Time{}, invalid
+	}
+	accountUUID, expiry, ok := strings.Cut(string(b), ".")
+	if !ok {
+		return "", time.Time{}, invalid
+	}
+	unix, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return "", time.Time{}, invalid
+	}
+	expiresAt := time.Unix(unix, 0).UTC()
+	if time.Now().After(expiresAt) {
+		return "", time.Time{}, errs.New(errors.New("pairing link expired"), errCode.Unauthenticated)
+	}
+	return accountUUID, expiresAt, nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/api/repository"
+	server "qrpay-wpp/internal/api/system"
+	"qrpay-wpp/internal/errCode"
+	"sync"
+	"time"
+)
+
+// ReconcileReport is the outcome of a reconciliation. Orphaned devices are
+// paired phones without an active account, orphaned rows are active accounts
+// whose device is gone from the session store and must pair again.
+type ReconcileReport struct {
+	CheckedAt       time.Time
+	Fixed           []string
+	OrphanedDevices []string
+	OrphanedRows    []*model.WhatsApp
+}
+
+type Reconciler interface {
+	Reset(ctx context.Context) error
+	Reconcile(ctx context.Context) (*ReconcileReport, error)
+	Report() *ReconcileReport
+	Start(ctx context.Context)
+}
+
+type reconciler struct {
+	pool     *pgxpool.Pool
+	repo     repository.WhatsApp
+	system   server.WhatsAppSystem
+	cluster  Cluster
+	interval time.Duration
+	leaseTTL time.Duration
+
+	mu     sync.RWMutex
+	report *ReconcileReport
+}
+
+func NewReconciler(pool *pgxpool.Pool, repo repository.WhatsApp, system server.WhatsAppSystem, cluster Cluster, interval time.Duration, leaseTTL time.Duration) Reconciler {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	return &reconciler{
+		pool:     pool,
+		repo:     repo,
+		system:   system,
+		cluster:  cluster,
+		interval: interval,
+		leaseTTL: leaseTTL,
+	}
+}
+
+// Reset clears the connected flags left behind by a previous run, sparing the
+// accounts owned by other live nodes. It must run before any session is restored.
+func (s *reconciler) Reset(ctx context.Context) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	n, err := s.repo.TResetConnected(ctx, tx, s.cluster.NodeID(), time.Now().UTC().Add(-s.leaseTTL))
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	if n > 0 {
+		fmt.Printf("Reset %d stale connected flags\n", n)
+	}
+	return nil
+}
+
+// Reconcile aligns the connected flag of every active account owned by this node
+// with its live connection and reports the accounts and devices that do not match each other.
+func (s *reconciler) Reconcile(ctx context.Context) (*ReconcileReport, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	wpps, err := s.repo.TGetAllActive(ctx, tx)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	report := &ReconcileReport{CheckedAt: time.Now().UTC()}
+	paired := map[string]bool{}
+	for _, phone := range s.system.PairedPhones() {
+		paired[phone] = true
+	}
+	phones := map[string]bool{}
+	for _, wpp := range wpps {
+		phones[wpp.Phone] = true
+		if !paired[wpp.Phone] {
+			report.OrphanedRows = append(report.OrphanedRows, wpp)
+		}
+		if !s.cluster.Owns(wpp.AccountUUID) {
+			continue
+		}
+		live := s.system.State(wpp.AccountUUID) == server.StateConnected
+		if wpp.Connected == live {
+			continue
+		}
+		wpp.Connected = live
+		err = s.repo.TUpdate(ctx, tx, wpp)
+		if err != nil {
+			return nil, errCode.Wrap(err)
+		}
+		report.Fixed = append(report.Fixed, wpp.AccountUUID)
+	}
+	for phone := range paired {
+		if !phones[phone] {
+			report.OrphanedDevices = append(report.OrphanedDevices, phone)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	s.mu.Lock()
+	s.report = report
+	s.mu.Unlock()
+	return report, nil
+}
+
+// Report returns the outcome of the last reconciliation, nil before the first one.
+func (s *reconciler) Report() *ReconcileReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.report
+}
+
+func (s *reconciler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := s.Reconcile(ctx)
+			if err != nil {
+				fmt.Printf("Reconciliation failed: %v\n", err)
+				continue
+			}
+			if len(report.Fixed) > 0 {
+				fmt.Printf("Reconciliation fixed %d accounts\n", len(report.Fixed))
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc/codes"
+	"qrpay-wpp/configs"
+	"qrpay-wpp/internal/api/repository"
+	server "qrpay-wpp/internal/api/system"
+	"qrpay-wpp/internal/errCode"
+	"time"
+)
+
+const day = 24 * time.Hour
+
+type WarmupStatus struct {
+	Policy         string
+	AgeDays        int
+	Stage          int
+	DailyAllowance int
+	SentToday      int64
+	Remaining      int64
+	WarmedUp       bool
+	StageEndsAt    time.Time
+}
+
+type Warmup interface {
+	Status(ctx context.Context, accountUUID string) (*WarmupStatus, error)
+	Check(ctx context.Context, accountUUID string) error
+}
+
+type warmup struct {
+	pool   *pgxpool.Pool
+	repo   repository.WhatsApp
+	outbox repository.Outbox
+	config configs.Warmup
+}
+
+func NewWarmup(pool *pgxpool.Pool, repo repository.WhatsApp, outbox repository.Outbox, config configs.Warmup) Warmup {
+	return &warmup{
+		pool:   pool,
+		repo:   repo,
+		outbox: outbox,
+		config: config,
+	}
+}
+
+func (s *warmup) policy(accountUUID string) *configs.WarmupPolicy {
+	name, ok := s.config.Accounts[accountUUID]
+	if !ok {
+		name = s.config.DefaultPolicy
+	}
+	for i := range s.config.Policies {
+		if s.config.Policies[i].Name == name {
+			return &s.config.Policies[i]
+		}
+	}
+	return nil
+}
+
+// Status computes the allowance of the account from the age of its number.
+// Accounts past the last stage of their policy are warmed up and have no allowance.
+func (s *warmup) Status(ctx context.Context, accountUUID string) (*WarmupStatus, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	wpp, err := s.repo.TGetByAccountId(ctx, tx, accountUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	now := time.Now().UTC()
+	st := &WarmupStatus{
+		AgeDays:  int(now.Sub(wpp.CreatedAt) / day),
+		WarmedUp: true,
+	}
+	policy := s.policy(accountUUID)
+	if policy != nil {
+		st.Policy = policy.Name
+		for i, stage := range policy.Stages {
+			if st.AgeDays < stage.Days {
+				st.Stage = i + 1
+				st.DailyAllowance = stage.DailyLimit
+				st.WarmedUp = false
+				st.StageEndsAt = wpp.CreatedAt.Add(time.Duration(stage.Days) * day)
+				break
+			}
+		}
+	}
+
+	st.SentToday, err = s.outbox.TCountSentSince(ctx, tx, accountUUID, now.Truncate(day))
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	if !st.WarmedUp && st.SentToday < int64(st.DailyAllowance) {
+		st.Remaining = int64(st.DailyAllowance) - st.SentToday
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return st, nil
+}
+
+// Check returns a ResourceExhausted error, retryable on the next day, once the
+// account used its warm-up allowance for today.
+func (s *warmup) Check(ctx context.Context, accountUUID string) error {
+	if s.policy(accountUUID) == nil {
+		return nil
+	}
+	st, err := s.Status(ctx, accountUUID)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	if st.WarmedUp || st.Remaining > 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	return server.RetryAfter(codes.ResourceExhausted, "warm-up daily allowance reached", now.Truncate(day).Add(day).Sub(now))
+}
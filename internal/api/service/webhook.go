@@ -0,0 +1,526 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	mrand "math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/api/repository"
+	"qrpay-wpp/internal/errCode"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unicode"
+)
+
+const (
+	webhookBatchSize   = 20
+	webhookMaxBackoff  = time.Hour
+	webhookPollingRate = time.Second
+	webhookMaxAttempts = 5
+)
+
+type Webhook interface {
+	Create(ctx context.Context, accountUUID string, url string, secret string) (*model.Webhook, error)
+	List(ctx context.Context, accountUUID string) ([]*model.Webhook, error)
+	Delete(ctx context.Context, accountUUID string, webhookUUID string) error
+	ListDeadLetters(ctx context.Context, accountUUID string) ([]*model.WebhookDeadLetter, error)
+	Redeliver(ctx context.Context, accountUUID string, deadLetterUUID string) error
+	Publish(ctx context.Context, accountUUID string, evt any) error
+	Start(ctx context.Context)
+}
+
+type WebhookOptions struct {
+	MaxAttempts int
+	Timeout     time.Duration
+	Backoff     time.Duration
+}
+
+// txBeginner starts the transactions of a service, a *pgxpool.Pool outside of
+// its tests.
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type webhook struct {
+	pool        txBeginner
+	repo        repository.Webhook
+	deliveries  repository.WebhookDelivery
+	deadLetters repository.WebhookDeadLetter
+	client      *http.Client
+	options     WebhookOptions
+}
+
+func NewWebhook(pool *pgxpool.Pool, repo repository.Webhook, deliveries repository.WebhookDelivery, deadLetters repository.WebhookDeadLetter, options WebhookOptions) Webhook {
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = webhookMaxAttempts
+	}
+	return &webhook{
+		pool:        pool,
+		repo:        repo,
+		deliveries:  deliveries,
+		deadLetters: deadLetters,
+		client:      newWebhookClient(options.Timeout),
+		options:     options,
+	}
+}
+
+var (
+	errWebhookAddress     = errors.New("webhook address is not public")
+	errWebhookUnavailable = errors.New("webhook deleted or inactive")
+)
+
+// publicAddress reports whether webhooks may be delivered to ip. Loopback,
+// private, link-local, cloud metadata included, unspecified and multicast
+// addresses belong to the network of the server.
+func publicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// httpsURL parses a webhook URL, which must be an absolute https one.
+func httpsURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return nil, errors.New("webhook URL must be an absolute https URL")
+	}
+	return u, nil
+}
+
+// checkWebhookURL requires an https URL whose host resolves to public addresses only.
+func checkWebhookURL(ctx context.Context, raw string) error {
+	u, err := httpsURL(raw)
+	if err != nil {
+		return err
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return fmt.Errorf("unable to resolve webhook host %s: %v", u.Hostname(), err)
+	}
+	for _, addr := range addrs {
+		if !publicAddress(addr.IP) {
+			return fmt.Errorf("%w: %s resolves to %s", errWebhookAddress, u.Hostname(), addr.IP)
+		}
+	}
+	return nil
+}
+
+// newWebhookClient returns a client that only connects to public addresses,
+// checked once the host is resolved so that its DNS records cannot point
+// deliveries back into the network of the server. Redirects must stay on https.
+func newWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !publicAddress(ip) {
+				return fmt.Errorf("%w: %s", errWebhookAddress, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return errors.New("webhook redirected to a non https URL")
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		},
+	}
+}
+
+type webhookEnvelope struct {
+	Event       string    `json:"event"`
+	AccountUUID string    `json:"account_uuid"`
+	Timestamp   time.Time `json:"timestamp"`
+	Data        any       `json:"data"`
+}
+
+// eventName turns a whatsmeow event type such as *events.PairSuccess into pair_success.
+func eventName(evt any) string {
+	t := reflect.TypeOf(evt)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	b := new(strings.Builder)
+	for i, r := range t.Name() {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func signWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *webhook) Create(ctx context.Context, accountUUID string, url string, secret string) (*model.Webhook, error) {
+	err := checkWebhookURL(ctx, url)
+	if err != nil {
+		return nil, errs.New(err, errCode.InvalidArgument)
+	}
+	if secret == "" {
+		b := make([]byte, 32)
+		_, err = rand.Read(b)
+		if err != nil {
+			return nil, errs.New(err, errCode.Internal)
+		}
+		secret = hex.EncodeToString(b)
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	hook := &model.Webhook{
+		AccountUUID: accountUUID,
+		URL:         url,
+		Secret:      secret,
+		Active:      true,
+	}
+	err = s.repo.TCreate(ctx, tx, hook)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return hook, nil
+}
+
+func (s *webhook) List(ctx context.Context, accountUUID string) ([]*model.Webhook, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	hooks, err := s.repo.TGetAllByAccount(ctx, tx, accountUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return hooks, nil
+}
+
+func (s *webhook) Delete(ctx context.Context, accountUUID string, webhookUUID string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	hook, err := s.repo.TGetByUUID(ctx, tx, webhookUUID)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	if hook.AccountUUID != accountUUID {
+		return errs.New(errors.New("webhook not found"), errCode.NotFound)
+	}
+	err = s.repo.TDelete(ctx, tx, hook)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+func (s *webhook) ListDeadLetters(ctx context.Context, accountUUID string) ([]*model.WebhookDeadLetter, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	letters, err := s.deadLetters.TGetAllByAccount(ctx, tx, accountUUID)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	return letters, nil
+}
+
+// Redeliver moves a dead letter back to the delivery queue with a fresh attempt budget.
+func (s *webhook) Redeliver(ctx context.Context, accountUUID string, deadLetterUUID string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	letter, err := s.deadLetters.TGetByUUID(ctx, tx, deadLetterUUID)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	if letter.AccountUUID != accountUUID {
+		return errs.New(errors.New("dead letter not found"), errCode.NotFound)
+	}
+	delivery := &model.WebhookDelivery{
+		UUID:        letter.UUID,
+		WebhookUUID: letter.WebhookUUID,
+		AccountUUID: letter.AccountUUID,
+		Event:       letter.Event,
+		Payload:     letter.Payload,
+	}
+	err = s.deliveries.TCreate(ctx, tx, delivery)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	err = s.deadLetters.TDelete(ctx, tx, letter)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+// Publish queues the event for every active webhook of the account.
+func (s *webhook) Publish(ctx context.Context, accountUUID string, evt any) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	hooks, err := s.repo.TGetAllByAccount(ctx, tx, accountUUID)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	name := eventName(evt)
+	envelope := &webhookEnvelope{
+		Event:       name,
+		AccountUUID: accountUUID,
+		Timestamp:   time.Now().UTC(),
+		Data:        evt,
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		envelope.Data = nil
+		payload, err = json.Marshal(envelope)
+		if err != nil {
+			return errs.New(err, errCode.Internal)
+		}
+	}
+	for _, hook := range hooks {
+		if !hook.Active {
+			continue
+		}
+		delivery := &model.WebhookDelivery{
+			WebhookUUID: hook.UUID,
+			AccountUUID: accountUUID,
+			Event:       name,
+			Payload:     payload,
+		}
+		err = s.deliveries.TCreate(ctx, tx, delivery)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+// Start delivers due webhook events until ctx is done.
+func (s *webhook) Start(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollingRate)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.deliverDue(ctx)
+			if err != nil {
+				fmt.Printf("Webhook delivery failed: %v\n", err)
+			}
+		}
+	}
+}
+
+func (s *webhook) deliverDue(ctx context.Context) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	// The lease must outlive a full batch of timed out requests.
+	lease := s.options.Timeout*webhookBatchSize + time.Minute
+	deliveries, err := s.deliveries.TClaimDue(ctx, tx, webhookBatchSize, lease)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	hooks := map[string]*model.Webhook{}
+	for _, d := range deliveries {
+		if _, ok := hooks[d.WebhookUUID]; ok {
+			continue
+		}
+		hook, err := s.repo.TGetByUUID(ctx, tx, d.WebhookUUID)
+		if err != nil && !errCode.Is(err, errCode.NotChanged) {
+			return errCode.Wrap(err)
+		}
+		hooks[d.WebhookUUID] = hook
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+
+	for _, d := range deliveries {
+		hook := hooks[d.WebhookUUID]
+		sendErr := errWebhookUnavailable
+		if hook != nil && hook.Active {
+			sendErr = s.send(ctx, hook, d)
+		}
+		err = s.finish(ctx, d, sendErr)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+	}
+	return nil
+}
+
+func (s *webhook) send(ctx context.Context, hook *model.Webhook, d *model.WebhookDelivery) error {
+	// Hooks created before URLs were checked may still use plain http
+	u, err := httpsURL(hook.URL)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(d.Payload))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", d.Event)
+	req.Header.Set("X-Webhook-Delivery", d.UUID)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", signWebhook(hook.Secret, timestamp, d.Payload))
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return nil
+}
+
+// finish removes a sent delivery, reschedules a failed one with exponential backoff
+// or moves it to the dead letters once it runs out of attempts. Deliveries of
+// deleted or inactive webhooks go to the dead letters right away, so that they
+// can be redelivered.
+func (s *webhook) finish(ctx context.Context, d *model.WebhookDelivery, sendErr error) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	d.Attempts++
+	switch {
+	case sendErr == nil:
+		err = s.deliveries.TDelete(ctx, tx, d)
+	case d.Attempts >= s.options.MaxAttempts || errors.Is(sendErr, errWebhookUnavailable):
+		letter := &model.WebhookDeadLetter{
+			UUID:        d.UUID,
+			WebhookUUID: d.WebhookUUID,
+			AccountUUID: d.AccountUUID,
+			Event:       d.Event,
+			Payload:     d.Payload,
+			Attempts:    d.Attempts,
+			LastError:   sendErr.Error(),
+		}
+		err = s.deadLetters.TCreate(ctx, tx, letter)
+		if err == nil {
+			err = s.deliveries.TDelete(ctx, tx, d)
+		}
+	default:
+		d.LastError = sendErr.Error()
+		d.NextAttemptAt = time.Now().UTC().Add(s.backoff(d.Attempts))
+		err = s.deliveries.TUpdate(ctx, tx, d)
+	}
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+func (s *webhook) backoff(attempts int) time.Duration {
+	delay := s.options.Backoff << (attempts - 1)
+	if delay <= 0 || delay > webhookMaxBackoff {
+		delay = webhookMaxBackoff
+	}
+	jitter := time.Duration(mrand.Int63n(int64(delay)/5 + 1))
+	return delay + jitter
+}
@@ -0,0 +1,520 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	errs "github.com/cristiancll/go-errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/errCode"
+	"sync"
+	"testing"
+	"time"
+)
+
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookServer answers with the given statuses in turn, the last one repeated,
+// and records the requests it receives.
+func webhookServer(t *testing.T, statuses ...int) (*httptest.Server, func() []webhookRequest) {
+	var mu sync.Mutex
+	var requests []webhookRequest
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("unable to read body: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, webhookRequest{header: r.Header.Clone(), body: body})
+		status := statuses[len(statuses)-1]
+		if len(requests) <= len(statuses) {
+			status = statuses[len(requests)-1]
+		}
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, func() []webhookRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]webhookRequest(nil), requests...)
+	}
+}
+
+// testWebhook trusts the test server, which the client of NewWebhook refuses to reach on loopback.
+func testWebhook(ts *httptest.Server, options WebhookOptions) *webhook {
+	s := NewWebhook(nil, nil, nil, nil, options).(*webhook)
+	if ts != nil {
+		s.client = ts.Client()
+	}
+	return s
+}
+
+func testDelivery() *model.WebhookDelivery {
+	return &model.WebhookDelivery{
+		UUID:        "5f0c6f7e-8d1b-4c2a-9e3f-1a2b3c4d5e6f",
+		WebhookUUID: "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d",
+		AccountUUID: "0b7c5d4e-2f1a-4c3b-9d8e-7f6a5b4c3d2e",
+		Event:       "message",
+		Payload:     []byte(`{"event":"message","data":{"text":"hello"}}`),
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	ts, requests := webhookServer(t, http.StatusOK)
+	s := testWebhook(ts, WebhookOptions{MaxAttempts: 3, Timeout: time.Second, Backoff: time.Second})
+	hook := &model.Webhook{URL: ts.URL, Secret: "secret", Active: true}
+	d := testDelivery()
+
+	err := s.send(context.Background(), hook, d)
+	if err != nil {
+		t.Fatalf("send failed: %v", err)
+	}
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("got %d requests, want 1", len(got))
+	}
+	req := got[0]
+	if string(req.body) != string(d.Payload) {
+		t.Errorf("got body %s, want %s", req.body, d.Payload)
+	}
+	if req.header.Get("X-Webhook-Event") != d.Event {
+		t.Errorf("got event %q, want %q", req.header.Get("X-Webhook-Event"), d.Event)
+	}
+	if req.header.Get("X-Webhook-Delivery") != d.UUID {
+		t.Errorf("got delivery %q, want %q", req.header.Get("X-Webhook-Delivery"), d.UUID)
+	}
+	timestamp := req.header.Get("X-Webhook-Timestamp")
+	if timestamp == "" {
+		t.Fatal("missing timestamp")
+	}
+	mac := hmac.New(sha256.New, []byte(hook.Secret))
+	mac.Write([]byte(timestamp + "." + string(req.body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if sig := req.header.Get("X-Webhook-Signature"); !hmac.Equal([]byte(sig), []byte(want)) {
+		t.Errorf("got signature %q, want %q", sig, want)
+	}
+}
+
+func TestWebhookRetriesServerErrors(t *testing.T) {
+	ts, requests := webhookServer(t, http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusOK)
+	s := testWebhook(ts, WebhookOptions{MaxAttempts: 3, Timeout: time.Second, Backoff: time.Second})
+	hook := &model.Webhook{URL: ts.URL, Secret: "secret", Active: true}
+	d := testDelivery()
+
+	var previous time.Duration
+	for attempt := 1; attempt <= 2; attempt++ {
+		err := s.send(context.Background(), hook, d)
+		if err == nil {
+			t.Fatalf("attempt %d: expected a server error to fail the delivery", attempt)
+		}
+		delay := s.backoff(attempt)
+		base := s.options.Backoff << (attempt - 1)
+		if delay < base || delay > base+base/5 {
+			t.Errorf("attempt %d: got backoff %v, want %v plus at most 20%% jitter", attempt, delay, base)
+		}
+		if delay <= previous {
+			t.Errorf("attempt %d: backoff %v does not grow from %v", attempt, delay, previous)
+		}
+		previous = delay
+	}
+	err := s.send(context.Background(), hook, d)
+	if err != nil {
+		t.Fatalf("retry failed: %v", err)
+	}
+	if n := len(requests()); n != 3 {
+		t.Errorf("got %d requests, want 3", n)
+	}
+}
+
+func TestWebhookBackoffIsCapped(t *testing.T) {
+	s := testWebhook(nil, WebhookOptions{Backoff: time.Minute})
+	delay := s.backoff(40)
+	if delay < webhookMaxBackoff || delay > webhookMaxBackoff+webhookMaxBackoff/5 {
+		t.Errorf("got backoff %v, want %v plus at most 20%% jitter", delay, webhookMaxBackoff)
+	}
+}
+
+func TestWebhookURLMustBePublic(t *testing.T) {
+	urls := []string{
+		"http://example.com/hook",
+		"https:///hook",
+		"https://127.0.0.1/hook",
+		"https://localhost/hook",
+		"https://[::1]/hook",
+		"https://10.0.0.1/hook",
+		"https://192.168.1.10/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[fd00:ec2::254]/hook",
+		"https://0.0.0.0/hook",
+	}
+	for _, u := range urls {
+		if err := checkWebhookURL(context.Background(), u); err == nil {
+			t.Errorf("checkWebhookURL(%q) accepted an URL that must be rejected", u)
+		}
+	}
+	if err := checkWebhookURL(context.Background(), "https://93.184.216.34/hook"); err != nil {
+		t.Errorf("unexpected error for a public address: %v", err)
+	}
+}
+
+func TestWebhookClientRefusesInternalAddresses(t *testing.T) {
+	ts, requests := webhookServer(t, http.StatusOK)
+	s := NewWebhook(nil, nil, nil, nil, WebhookOptions{Timeout: time.Second}).(*webhook)
+	hook := &model.Webhook{URL: ts.URL, Secret: "secret", Active: true}
+
+	err := s.send(context.Background(), hook, testDelivery())
+	if !errors.Is(err, errWebhookAddress) {
+		t.Errorf("got %v, want the loopback address to be refused", err)
+	}
+	if n := len(requests()); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+}
+
+// fakeTx stands for the transactions of the fake repositories, which keep their
+// rows in memory and apply every change at once.
+type fakeTx struct {
+	pgx.Tx
+}
+
+func (fakeTx) Commit(context.Context) error   { return nil }
+func (fakeTx) Rollback(context.Context) error { return nil }
+
+type fakePool struct{}
+
+func (fakePool) Begin(context.Context) (pgx.Tx, error) { return fakeTx{}, nil }
+
+func notFound() error {
+	return errs.New(pgx.ErrNoRows, errCode.NotChanged)
+}
+
+type fakeWebhooks struct {
+	mu    sync.Mutex
+	hooks map[string]*model.Webhook
+}
+
+func (r *fakeWebhooks) Migrate(context.Context) error { return nil }
+
+func (r *fakeWebhooks) TCreate(_ context.Context, _ pgx.Tx, hook *model.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if hook.UUID == "" {
+		hook.UUID = uuid.NewString()
+	}
+	r.hooks[hook.UUID] = hook
+	return nil
+}
+
+func (r *fakeWebhooks) TDelete(_ context.Context, _ pgx.Tx, hook *model.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.hooks, hook.UUID)
+	return nil
+}
+
+func (r *fakeWebhooks) TGetByUUID(_ context.Context, _ pgx.Tx, uuid string) (*model.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hook, ok := r.hooks[uuid]
+	if !ok {
+		return nil, notFound()
+	}
+	return hook, nil
+}
+
+func (r *fakeWebhooks) TGetAllByAccount(_ context.Context, _ pgx.Tx, accountUUID string) ([]*model.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var hooks []*model.Webhook
+	for _, hook := range r.hooks {
+		if hook.AccountUUID == accountUUID {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks, nil
+}
+
+type fakeDeliveries struct {
+	mu   sync.Mutex
+	rows map[string]*model.WebhookDelivery
+}
+
+func (r *fakeDeliveries) Migrate(context.Context) error { return nil }
+
+func (r *fakeDeliveries) TCreate(_ context.Context, _ pgx.Tx, d *model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if d.UUID == "" {
+		d.UUID = uuid.NewString()
+	}
+	row := *d
+	r.rows[d.UUID] = &row
+	return nil
+}
+
+func (r *fakeDeliveries) TUpdate(_ context.Context, _ pgx.Tx, d *model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.rows[d.UUID]; !ok {
+		return notFound()
+	}
+	row := *d
+	r.rows[d.UUID] = &row
+	return nil
+}
+
+func (r *fakeDeliveries) TDelete(_ context.Context, _ pgx.Tx, d *model.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.rows, d.UUID)
+	return nil
+}
+
+func (r *fakeDeliveries) TClaimDue(_ context.Context, _ pgx.Tx, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now().UTC()
+	var due []*model.WebhookDelivery
+	for _, row := range r.rows {
+		if len(due) == limit {
+			break
+		}
+		if row.NextAttemptAt.After(now) {
+			continue
+		}
+		row.NextAttemptAt = now.Add(lease)
+		claimed := *row
+		due = append(due, &claimed)
+	}
+	return due, nil
+}
+
+// get returns the row of the delivery, nil when there is none.
+func (r *fakeDeliveries) get(uuid string) *model.WebhookDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rows[uuid]
+}
+
+// expire makes every delivery due again, as if their backoff had elapsed.
+func (r *fakeDeliveries) expire() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, row := range r.rows {
+		row.NextAttemptAt = time.Time{}
+	}
+}
+
+type fakeDeadLetters struct {
+	mu   sync.Mutex
+	rows map[string]*model.WebhookDeadLetter
+}
+
+func (r *fakeDeadLetters) Migrate(context.Context) error { return nil }
+
+func (r *fakeDeadLetters) TCreate(_ context.Context, _ pgx.Tx, letter *model.WebhookDeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	row := *letter
+	r.rows[letter.UUID] = &row
+	return nil
+}
+
+func (r *fakeDeadLetters) TDelete(_ context.Context, _ pgx.Tx, letter *model.WebhookDeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.rows, letter.UUID)
+	return nil
+}
+
+func (r *fakeDeadLetters) TGetByUUID(_ context.Context, _ pgx.Tx, uuid string) (*model.WebhookDeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	letter, ok := r.rows[uuid]
+	if !ok {
+		return nil, notFound()
+	}
+	row := *letter
+	return &row, nil
+}
+
+func (r *fakeDeadLetters) TGetAllByAccount(_ context.Context, _ pgx.Tx, accountUUID string) ([]*model.WebhookDeadLetter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var letters []*model.WebhookDeadLetter
+	for _, letter := range r.rows {
+		if letter.AccountUUID == accountUUID {
+			row := *letter
+			letters = append(letters, &row)
+		}
+	}
+	return letters, nil
+}
+
+type webhookFixture struct {
+	service     *webhook
+	hooks       *fakeWebhooks
+	deliveries  *fakeDeliveries
+	deadLetters *fakeDeadLetters
+}
+
+// newWebhookFixture builds the service over in-memory repositories, holding one
+// active hook of the test account that points at ts.
+func newWebhookFixture(ts *httptest.Server, options WebhookOptions) (*webhookFixture, *model.Webhook) {
+	f := &webhookFixture{
+		hooks:       &fakeWebhooks{hooks: map[string]*model.Webhook{}},
+		deliveries:  &fakeDeliveries{rows: map[string]*model.WebhookDelivery{}},
+		deadLetters: &fakeDeadLetters{rows: map[string]*model.WebhookDeadLetter{}},
+	}
+	f.service = NewWebhook(nil, f.hooks, f.deliveries, f.deadLetters, options).(*webhook)
+	f.service.pool = fakePool{}
+	f.service.client = ts.Client()
+	hook := &model.Webhook{AccountUUID: testDelivery().AccountUUID, URL: ts.URL, Secret: "secret", Active: true}
+	_ = f.hooks.TCreate(context.Background(), nil, hook)
+	return f, hook
+}
+
+// queue adds a due delivery of the test event for the hook.
+func (f *webhookFixture) queue(hookUUID string) *model.WebhookDelivery {
+	d := testDelivery()
+	d.UUID = ""
+	d.WebhookUUID = hookUUID
+	_ = f.deliveries.TCreate(context.Background(), nil, d)
+	return d
+}
+
+func TestWebhookRecordsFailedAttempts(t *testing.T) {
+	ts, requests := webhookServer(t, http.StatusInternalServerError)
+	f, hook := newWebhookFixture(ts, WebhookOptions{MaxAttempts: 3, Timeout: time.Second, Backoff: time.Minute})
+	d := f.queue(hook.UUID)
+	ctx := context.Background()
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		before := time.Now().UTC()
+		err := f.service.deliverDue(ctx)
+		if err != nil {
+			t.Fatalf("attempt %d: deliverDue failed: %v", attempt, err)
+		}
+		row := f.deliveries.get(d.UUID)
+		if row == nil {
+			t.Fatalf("attempt %d: delivery removed before running out of attempts", attempt)
+		}
+		if row.Attempts != attempt {
+			t.Errorf("attempt %d: got %d attempts recorded", attempt, row.Attempts)
+		}
+		if row.LastError == "" {
+			t.Errorf("attempt %d: last error not recorded", attempt)
+		}
+		if min := before.Add(time.Minute << (attempt - 1)); row.NextAttemptAt.Before(min) {
+			t.Errorf("attempt %d: next attempt at %v, want after %v", attempt, row.NextAttemptAt, min)
+		}
+		// Not due until the backoff elapses
+		err = f.service.deliverDue(ctx)
+		if err != nil {
+			t.Fatalf("attempt %d: deliverDue failed: %v", attempt, err)
+		}
+		if n := len(requests()); n != attempt {
+			t.Fatalf("attempt %d: got %d requests, want %d", attempt, n, attempt)
+		}
+		f.deliveries.expire()
+	}
+	if len(f.deadLetters.rows) != 0 {
+		t.Errorf("got %d dead letters, want none", len(f.deadLetters.rows))
+	}
+}
+
+func TestWebhookDeadLettersAndRedelivers(t *testing.T) {
+	ts, requests := webhookServer(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK)
+	f, hook := newWebhookFixture(ts, WebhookOptions{MaxAttempts: 2, Timeout: time.Second, Backoff: time.Millisecond})
+	d := f.queue(hook.UUID)
+	ctx := context.Background()
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		err := f.service.deliverDue(ctx)
+		if err != nil {
+			t.Fatalf("attempt %d: deliverDue failed: %v", attempt, err)
+		}
+		f.deliveries.expire()
+	}
+	if f.deliveries.get(d.UUID) != nil {
+		t.Fatal("delivery still queued after running out of attempts")
+	}
+	letters, err := f.service.ListDeadLetters(ctx, d.AccountUUID)
+	if err != nil {
+		t.Fatalf("ListDeadLetters failed: %v", err)
+	}
+	if len(letters) != 1 {
+		t.Fatalf("got %d dead letters, want 1", len(letters))
+	}
+	letter := letters[0]
+	if letter.UUID != d.UUID || letter.Attempts != 2 || letter.LastError == "" || string(letter.Payload) != string(d.Payload) {
+		t.Errorf("unexpected dead letter %+v", letter)
+	}
+
+	err = f.service.Redeliver(ctx, "5c4b3a29-1807-4f6e-8d5c-4b3a29180700", letter.UUID)
+	if !errCode.Is(err, errCode.NotFound) {
+		t.Errorf("got %v, want the dead letter of another account not to be found", err)
+	}
+	err = f.service.Redeliver(ctx, d.AccountUUID, letter.UUID)
+	if err != nil {
+		t.Fatalf("Redeliver failed: %v", err)
+	}
+	if len(f.deadLetters.rows) != 0 {
+		t.Error("dead letter kept after being redelivered")
+	}
+	row := f.deliveries.get(d.UUID)
+	if row == nil || row.Attempts != 0 {
+		t.Fatalf("got delivery %+v, want one queued with a fresh attempt budget", row)
+	}
+
+	err = f.service.deliverDue(ctx)
+	if err != nil {
+		t.Fatalf("deliverDue failed: %v", err)
+	}
+	if f.deliveries.get(d.UUID) != nil {
+		t.Error("delivery still queued after being delivered")
+	}
+	if n := len(requests()); n != 3 {
+		t.Errorf("got %d requests, want 3", n)
+	}
+}
+
+func TestWebhookDeadLettersUnavailableHooks(t *testing.T) {
+	ts, requests := webhookServer(t, http.StatusOK)
+	f, hook := newWebhookFixture(ts, WebhookOptions{MaxAttempts: 3, Timeout: time.Second, Backoff: time.Millisecond})
+	hook.Active = false
+	inactive := f.queue(hook.UUID)
+	deleted := f.queue("7d6c5b4a-3928-4716-9504-f3e2d1c0b9a8")
+
+	err := f.service.deliverDue(context.Background())
+	if err != nil {
+		t.Fatalf("deliverDue failed: %v", err)
+	}
+	for _, d := range []*model.WebhookDelivery{inactive, deleted} {
+		if f.deliveries.get(d.UUID) != nil {
+			t.Errorf("delivery %s still queued", d.UUID)
+		}
+		letter := f.deadLetters.rows[d.UUID]
+		if letter == nil {
+			t.Errorf("delivery %s dropped instead of dead-lettered", d.UUID)
+			continue
+		}
+		if letter.LastError != errWebhookUnavailable.Error() {
+			t.Errorf("got last error %q, want %q", letter.LastError, errWebhookUnavailable)
+		}
+	}
+	if n := len(requests()); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+}
@@ -2,33 +2,68 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	errs "github.com/cristiancll/go-errors"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/grpc/codes"
+	"qrpay-wpp/internal/api/bus"
 	"qrpay-wpp/internal/api/model"
 	"qrpay-wpp/internal/api/repository"
 	server "qrpay-wpp/internal/api/system"
+	"qrpay-wpp/internal/common"
 	"qrpay-wpp/internal/errCode"
+	"sync"
+	"time"
 )
 
+// WhatsAppTopic is the bus topic of every event received from WhatsApp.
+const WhatsAppTopic = "whatsapp.event"
+
 type WhatsApp interface {
 	Connect(ctx context.Context, uuid string) error
-	Message(ctx context.Context, uuid string, to string, text string, media []byte) error
+	Message(ctx context.Context, uuid string, to string, text string, media []byte) (*model.OutboundMessage, error)
+	Reply(ctx context.Context, uuid string, from string, text string) (*model.OutboundMessage, error)
+	MessageStatus(ctx context.Context, uuid string, messageUUID string) (*model.OutboundMessage, error)
+	Restore(ctx context.Context, concurrency int, stagger time.Duration) error
+	Balance(ctx context.Context, concurrency int, stagger time.Duration) error
+	Start(ctx context.Context, interval time.Duration, concurrency int, stagger time.Duration)
 	GetQRCode(uuid string) (string, error)
+	Status(uuid string) server.Status
+	DispatchStats() server.DispatchStats
+	Events() (<-chan *bus.Message, func())
+	Shutdown(ctx context.Context) error
 }
 
 type whatsApp struct {
-	pool   *pgxpool.Pool
-	repo   repository.WhatsApp
-	system server.WhatsAppSystem
+	pool    txBeginner
+	repo    repository.WhatsApp
+	ban     repository.Ban
+	system  server.WhatsAppSystem
+	flow    Flow
+	conv    Conversation
+	consent Consent
+	webhook Webhook
+	outbox  Outbox
+	cluster Cluster
+	bus     bus.Bus
 }
 
-func NewWhatsApp(pool *pgxpool.Pool, repo repository.WhatsApp, system server.WhatsAppSystem) WhatsApp {
+func NewWhatsApp(pool *pgxpool.Pool, repo repository.WhatsApp, ban repository.Ban, system server.WhatsAppSystem, flow Flow, conv Conversation, consent Consent, webhook Webhook, outbox Outbox, cluster Cluster, bus bus.Bus) WhatsApp {
 	return &whatsApp{
-		pool:   pool,
-		repo:   repo,
-		system: system,
+		pool:    pool,
+		repo:    repo,
+		ban:     ban,
+		system:  system,
+		flow:    flow,
+		conv:    conv,
+		consent: consent,
+		webhook: webhook,
+		outbox:  outbox,
+		cluster: cluster,
+		bus:     bus,
 	}
 }
 
@@ -39,18 +74,28 @@ func (s *whatsApp) create(ctx context.Context, accountUUID string, phone string)
 	}
 	defer tx.Rollback(ctx)
 
-	wpp, err := s.repo.TGetByAccountId(ctx, tx, accountUUID)
-	if err != nil {
-		return nil, errs.Wrap(err, "")
+	// A new pairing replaces the number previously active for the account.
+	wpp, _ := s.repo.TGetByAccountId(ctx, tx, accountUUID)
+	if wpp != nil && wpp.Phone == phone {
+		return wpp, nil
+	}
+	if wpp != nil {
+		wpp.Active = false
+		wpp.Connected = false
+		err = s.repo.TUpdate(ctx, tx, wpp)
+		if err != nil {
+			return nil, errCode.Wrap(err)
+		}
 	}
 
 	wpp = &model.WhatsApp{
 		AccountUUID: accountUUID,
 		Phone:       phone,
+		Active:      true,
 	}
 	err = s.repo.TCreate(ctx, tx, wpp)
 	if err != nil {
-		return nil, errs.Wrap(err, "")
+		return nil, errCode.Wrap(err)
 	}
 
 	err = tx.Commit(ctx)
@@ -60,7 +105,9 @@ func (s *whatsApp) create(ctx context.Context, accountUUID string, phone string)
 	return wpp, nil
 }
 
-func (s *whatsApp) update(ctx context.Context, accountUUID string, isConnected, isActive, isBanned bool) error {
+// update records the connection of the account. A ban is lifted once the account
+// connects again or the ban expires, not by the disconnects it causes.
+func (s *whatsApp) update(ctx context.Context, accountUUID string, isConnected, isActive bool) error {
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return errs.New(err, errCode.Internal)
@@ -68,14 +115,74 @@ func (s *whatsApp) update(ctx context.Context, accountUUID string, isConnected,
 	defer tx.Rollback(ctx)
 	wpp, err := s.repo.TGetByAccountId(ctx, tx, accountUUID)
 	if err != nil {
-		return errs.Wrap(err, "")
+		return errCode.Wrap(err)
+	}
+	expired := wpp.BanExpiresAt != nil && !wpp.BanExpiresAt.After(time.Now().UTC())
+	if wpp.Banned && (isConnected || expired) {
+		err = s.liftBan(ctx, tx, wpp)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+		wpp.Banned = false
 	}
 	wpp.Active = isActive
 	wpp.Connected = isConnected
-	wpp.Banned = isBanned
 	err = s.repo.TUpdate(ctx, tx, wpp)
 	if err != nil {
-		return errs.Wrap(err, "")
+		return errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	return nil
+}
+
+// banned records a temporary ban on the account and in its ban history. The
+// account stays active so it resumes once the ban expires and it connects again.
+func (s *whatsApp) banned(ctx context.Context, accountUUID string, evt *events.TemporaryBan) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+	wpp, err := s.repo.TGetByAccountId(ctx, tx, accountUUID)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+
+	var expiresAt *time.Time
+	if evt.Expire > 0 {
+		t := time.Now().UTC().Add(evt.Expire)
+		expiresAt = &t
+	}
+	wpp.Connected = false
+	wpp.Banned = true
+	wpp.BanCode = int(evt.Code)
+	wpp.BanReason = evt.Code.String()
+	wpp.BanExpiresAt = expiresAt
+	err = s.repo.TUpdate(ctx, tx, wpp)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+
+	// A ban received while one is still open extends it instead of adding an entry.
+	ban, _ := s.ban.TGetOpenByAccount(ctx, tx, accountUUID)
+	if ban != nil {
+		ban.ExpiresAt = expiresAt
+		err = s.ban.TUpdate(ctx, tx, ban)
+	} else {
+		err = s.ban.TCreate(ctx, tx, &model.Ban{
+			AccountUUID: accountUUID,
+			Phone:       wpp.Phone,
+			Code:        wpp.BanCode,
+			Reason:      wpp.BanReason,
+			ExpiresAt:   expiresAt,
+		})
+	}
+	if err != nil {
+		return errCode.Wrap(err)
 	}
 
 	err = tx.Commit(ctx)
@@ -85,42 +192,163 @@ func (s *whatsApp) update(ctx context.Context, accountUUID string, isConnected,
 	return nil
 }
 
+func (s *whatsApp) liftBan(ctx context.Context, tx pgx.Tx, wpp *model.WhatsApp) error {
+	wpp.BanCode = 0
+	wpp.BanReason = ""
+	wpp.BanExpiresAt = nil
+	ban, _ := s.ban.TGetOpenByAccount(ctx, tx, wpp.AccountUUID)
+	if ban == nil {
+		return nil
+	}
+	now := time.Now().UTC()
+	ban.LiftedAt = &now
+	return s.ban.TUpdate(ctx, tx, ban)
+}
+
+// checkBan rejects sends of accounts under a ban that has not expired yet.
+func checkBan(wpp *model.WhatsApp) error {
+	if !wpp.Banned {
+		return nil
+	}
+	if wpp.BanExpiresAt == nil {
+		return errs.New(errors.New("account is temporarily banned: "+wpp.BanReason), errCode.Banned)
+	}
+	now := time.Now().UTC()
+	if !wpp.BanExpiresAt.After(now) {
+		return nil
+	}
+	return server.RetryAfter(codes.FailedPrecondition, "account is temporarily banned: "+wpp.BanReason, wpp.BanExpiresAt.Sub(now))
+}
+
+// send queues an automatic reply, bypassing the consent check done for Message.
+func (s *whatsApp) send(ctx context.Context, accountUUID string, phone string, text string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = s.outbox.Enqueue(ctx, tx, accountUUID, phone, text, nil)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	s.outbox.Notify(accountUUID)
+	return nil
+}
+
 func (s *whatsApp) handleUserResponse(ctx context.Context, accountUUID string, phone string, msg string) error {
+	if s.consent.IsOptOutKeyword(msg) {
+		reply, err := s.consent.OptOutByKeyword(ctx, accountUUID, phone, msg)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+		if reply != "" {
+			err = s.send(ctx, accountUUID, phone, reply)
+			if err != nil {
+				return errCode.Wrap(err)
+			}
+		}
+		return nil
+	}
+
+	conv, err := s.conv.Track(ctx, accountUUID, phone)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	if conv.Status != model.ConversationBot {
+		s.conv.Deliver(conv, msg)
+		return nil
+	}
+
+	res, err := s.flow.Handle(ctx, accountUUID, phone, msg)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	for _, reply := range res.Replies {
+		err = s.send(ctx, accountUUID, phone, reply)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+	}
+	if res.Handoff {
+		_, err = s.conv.Handoff(ctx, accountUUID, phone)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+	}
 	return nil
 }
 
 func (s *whatsApp) eventHandler(accountUUID string, evt any) {
 	ctx := context.Background()
+	err := s.webhook.Publish(ctx, accountUUID, evt)
+	if err != nil {
+		fmt.Printf("Webhook publishing failed: %v\n", err)
+	}
+	msg, err := bus.NewMessage(WhatsAppTopic, eventName(evt), accountUUID, evt)
+	if err == nil {
+		err = s.bus.Publish(ctx, msg)
+	}
+	if err != nil {
+		fmt.Printf("Bus publishing failed: %v\n", err)
+	}
 	switch v := evt.(type) {
 	case *events.PairSuccess:
 		phone := v.ID.User
 		_, err := s.create(ctx, accountUUID, phone)
 		if err != nil {
-			// TODO: log error
+			fmt.Printf("Unable to save the session of account %s: %v\n", accountUUID, err)
 			return
 		}
 	case *events.Connected:
 		fmt.Printf("Connected: %+v\n", v)
-		s.update(ctx, accountUUID, true, true, false)
+		s.update(ctx, accountUUID, true, true)
 	case *events.Disconnected:
 		fmt.Printf("Disconnected: %+v\n", v)
-		s.update(ctx, accountUUID, false, true, false)
+		s.update(ctx, accountUUID, false, true)
 	case *events.TemporaryBan:
 		fmt.Printf("TemporaryBan: %+v\n", v)
-		s.update(ctx, accountUUID, false, false, true)
+		err := s.banned(ctx, accountUUID, v)
+		if err != nil {
+			fmt.Printf("Ban recording failed: %v\n", err)
+		}
 	case *events.LoggedOut:
 		fmt.Printf("LoggedOut: %+v\n", v)
-		s.update(ctx, accountUUID, false, false, false)
+		s.update(ctx, accountUUID, false, false)
 	case *events.Message:
 		fmt.Printf("Message: %+v\n", v)
+		if v.Info.IsFromMe || v.Info.IsGroup {
+			return
+		}
 		phone := v.Info.MessageSource.Sender.User
-		msg := *v.Message.Conversation
-		s.handleUserResponse(ctx, accountUUID, phone, msg)
+		msg := v.Message.GetConversation()
+		if msg == "" {
+			msg = v.Message.GetExtendedTextMessage().GetText()
+		}
+		if msg == "" {
+			return
+		}
+		err := s.handleUserResponse(ctx, accountUUID, phone, msg)
+		if err != nil {
+			fmt.Printf("Message handling failed: %v\n", err)
+		}
 	}
 
 }
 
 func (s *whatsApp) Connect(ctx context.Context, uuid string) error {
+	ok, err := s.cluster.Acquire(ctx, uuid)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	if !ok {
+		return errs.New(errors.New("account is owned by another node"), errCode.NotOwner)
+	}
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
 		return errs.New(err, errCode.Internal)
@@ -130,12 +358,12 @@ func (s *whatsApp) Connect(ctx context.Context, uuid string) error {
 	if wpp != nil {
 		err = s.system.Connect(ctx, wpp.AccountUUID, wpp.Phone, s.eventHandler)
 		if err != nil {
-			return errs.Wrap(err, "")
+			return errCode.Wrap(err)
 		}
 	} else {
 		err = s.system.Connect(ctx, uuid, "", s.eventHandler)
 		if err != nil {
-			return errs.Wrap(err, "")
+			return errCode.Wrap(err)
 		}
 	}
 
@@ -146,19 +374,175 @@ func (s *whatsApp) Connect(ctx context.Context, uuid string) error {
 	return nil
 }
 
-func (s *whatsApp) Message(ctx context.Context, uuid string, to string, text string, media []byte) error {
+// Restore connects the active accounts whose device is still in the session
+// store and which no other node owns, until this node holds its fair share of
+// them. At most concurrency connections are started at a time, stagger apart.
+func (s *whatsApp) Restore(ctx context.Context, concurrency int, stagger time.Duration) error {
+	candidates, share, err := s.candidates(ctx)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	owned := len(s.cluster.Owned())
+	restored := 0
+	for _, wpp := range candidates {
+		if owned >= share {
+			break
+		}
+		if s.cluster.Owns(wpp.AccountUUID) {
+			continue
+		}
+		ok, err := s.cluster.Acquire(ctx, wpp.AccountUUID)
+		if err != nil {
+			fmt.Printf("Unable to acquire %s: %v\n", wpp.AccountUUID, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		owned++
+		restored++
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return ctx.Err()
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(wpp *model.WhatsApp) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			err := s.system.Connect(ctx, wpp.AccountUUID, wpp.Phone, s.eventHandler)
+			if err != nil {
+				fmt.Printf("Unable to restore %s: %v\n", wpp.AccountUUID, err)
+			}
+		}(wpp)
+		timer := time.NewTimer(stagger)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			wg.Wait()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	wg.Wait()
+	if restored > 0 {
+		fmt.Printf("Restored %d sessions\n", restored)
+	}
+	return nil
+}
+
+// candidates returns the newest active row of each account with a paired
+// device, and the number of them each live node should own.
+func (s *whatsApp) candidates(ctx context.Context) ([]*model.WhatsApp, int, error) {
 	tx, err := s.pool.Begin(ctx)
 	if err != nil {
-		return errs.New(err, errCode.Internal)
+		return nil, 0, errs.New(err, errCode.Internal)
 	}
 	defer tx.Rollback(ctx)
-	wpp, err := s.repo.TGetByAccountId(ctx, tx, uuid)
+	wpps, err := s.repo.TGetAllActive(ctx, tx)
+	if err != nil {
+		return nil, 0, errCode.Wrap(err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, 0, errs.New(err, errCode.Internal)
+	}
+
+	paired := map[string]bool{}
+	for _, phone := range s.system.PairedPhones() {
+		paired[phone] = true
+	}
+	seen := map[string]bool{}
+	candidates := make([]*model.WhatsApp, 0, len(wpps))
+	for _, wpp := range wpps {
+		if seen[wpp.AccountUUID] || !paired[wpp.Phone] {
+			continue
+		}
+		seen[wpp.AccountUUID] = true
+		candidates = append(candidates, wpp)
+	}
+
+	nodes, err := s.cluster.Nodes(ctx)
+	if err != nil {
+		return nil, 0, errCode.Wrap(err)
+	}
+	share := (len(candidates) + nodes - 1) / nodes
+	return candidates, share, nil
+}
+
+// Balance stops the sessions this node lost with its lock connection, releases
+// one account when it owns more than its share so that a new node can take it
+// over, and claims unowned accounts up to its share.
+func (s *whatsApp) Balance(ctx context.Context, concurrency int, stagger time.Duration) error {
+	lost, err := s.cluster.Check(ctx)
+	for _, accountUUID := range lost {
+		s.system.Disconnect(accountUUID)
+	}
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+
+	_, share, err := s.candidates(ctx)
+	if err != nil {
+		return errCode.Wrap(err)
+	}
+	owned := s.cluster.Owned()
+	if len(owned) > share {
+		// Releasing one account per round lets the others settle without flapping.
+		accountUUID := owned[len(owned)-1]
+		s.system.Disconnect(accountUUID)
+		err = s.cluster.Release(ctx, accountUUID)
+		if err != nil {
+			return errCode.Wrap(err)
+		}
+		return nil
+	}
+	return s.Restore(ctx, concurrency, stagger)
+}
+
+// Shutdown disconnects the sessions of this node and clears their connected
+// flags, then hands their accounts over to the other nodes.
+func (s *whatsApp) Shutdown(ctx context.Context) error {
+	owned := s.cluster.Owned()
+	err := s.system.Close(ctx)
 	if err != nil {
-		return errs.Wrap(err, "")
+		fmt.Printf("Unable to close the session store: %v\n", err)
+	}
+	for _, accountUUID := range owned {
+		err = s.disconnected(ctx, accountUUID)
+		if err != nil {
+			fmt.Printf("Unable to clear the connected flag of %s: %v\n", accountUUID, err)
+		}
 	}
-	err = s.system.SendMessage(ctx, wpp.AccountUUID, to, text, media)
+	err = s.cluster.Close(ctx)
 	if err != nil {
-		return errs.Wrap(err, "")
+		return errCode.Wrap(err)
+	}
+	return nil
+}
+
+// disconnected clears the connected flag of the account, leaving the rest of it as is.
+func (s *whatsApp) disconnected(ctx context.Context, accountUUID string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+
+	wpp, _ := s.repo.TGetByAccountId(ctx, tx, accountUUID)
+	if wpp == nil || !wpp.Connected {
+		return nil
+	}
+	wpp.Connected = false
+	err = s.repo.TUpdate(ctx, tx, wpp)
+	if err != nil {
+		return errCode.Wrap(err)
 	}
 
 	err = tx.Commit(ctx)
@@ -168,6 +552,81 @@ func (s *whatsApp) Message(ctx context.Context, uuid string, to string, text str
 	return nil
 }
 
+// Start balances the accounts among the live nodes every interval until ctx is done.
+func (s *whatsApp) Start(ctx context.Context, interval time.Duration, concurrency int, stagger time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := s.Balance(ctx, concurrency, stagger)
+			if err != nil {
+				fmt.Printf("Balancing failed: %v\n", err)
+			}
+		}
+	}
+}
+
+func (s *whatsApp) Message(ctx context.Context, uuid string, to string, text string, media []byte) (*model.OutboundMessage, error) {
+	// Queue the recipient keyed the way consent and the limiter key it
+	to = common.SanitizePhone(to)
+	err := s.consent.Check(ctx, uuid, to)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	defer tx.Rollback(ctx)
+	wpp, err := s.repo.TGetByAccountId(ctx, tx, uuid)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+	err = checkBan(wpp)
+	if err != nil {
+		return nil, err
+	}
+	err = s.outbox.Admit(ctx, wpp.AccountUUID)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := s.outbox.Enqueue(ctx, tx, wpp.AccountUUID, to, text, media)
+	if err != nil {
+		return nil, errCode.Wrap(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errs.New(err, errCode.Internal)
+	}
+	s.outbox.Notify(wpp.AccountUUID)
+	return msg, nil
+}
+
+func (s *whatsApp) Reply(ctx context.Context, uuid string, from string, text string) (*model.OutboundMessage, error) {
+	return s.Message(ctx, uuid, from, text, nil)
+}
+
+func (s *whatsApp) MessageStatus(ctx context.Context, uuid string, messageUUID string) (*model.OutboundMessage, error) {
+	return s.outbox.Get(ctx, uuid, messageUUID)
+}
+
 func (s *whatsApp) GetQRCode(uuid string) (string, error) {
 	return s.system.GetQRCode(uuid)
 }
+
+func (s *whatsApp) Status(uuid string) server.Status {
+	return s.system.Status(uuid)
+}
+
+func (s *whatsApp) DispatchStats() server.DispatchStats {
+	return s.system.DispatchStats()
+}
+
+// Events subscribes to the WhatsApp events of every account, received by any node.
+func (s *whatsApp) Events() (<-chan *bus.Message, func()) {
+	return s.bus.Subscribe(WhatsAppTopic)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"qrpay-wpp/internal/api/model"
+	"qrpay-wpp/internal/api/repository"
+	server "qrpay-wpp/internal/api/system"
+	"testing"
+)
+
+type fakeSystem struct {
+	server.WhatsAppSystem
+	err error
+}
+
+func (f fakeSystem) Connect(context.Context, string, string, func(string, any)) error {
+	return f.err
+}
+
+type fakeCluster struct {
+	Cluster
+}
+
+func (fakeCluster) Acquire(context.Context, string) (bool, error) {
+	return true, nil
+}
+
+type fakeWhatsAppRepo struct {
+	repository.WhatsApp
+}
+
+func (fakeWhatsAppRepo) TGetByAccountId(context.Context, pgx.Tx, string) (*model.WhatsApp, error) {
+	return nil, notFound()
+}
+
+func TestConnectKeepsSystemStatuses(t *testing.T) {
+	s := &whatsApp{
+		pool:    fakePool{},
+		repo:    fakeWhatsAppRepo{},
+		system:  fakeSystem{err: status.Error(codes.NotFound, "connection not found")},
+		cluster: fakeCluster{},
+	}
+	err := s.Connect(context.Background(), testDelivery().AccountUUID)
+	st, ok := status.FromError(err)
+	if !ok {
+		t.Fatalf("got %v, want the status of the system", err)
+	}
+	if st.Code() != codes.NotFound || st.Message() != "connection not found" {
+		t.Errorf("got %v: %s, want NotFound: connection not found", st.Code(), st.Message())
+	}
+}
@@ -1,14 +1,94 @@
 package server
 
 import (
+	"fmt"
+	"os"
+	"qrpay-wpp/configs"
+	"qrpay-wpp/internal/api/bus"
+	"qrpay-wpp/internal/api/flow"
+	proto "qrpay-wpp/internal/api/proto/generated"
 	"qrpay-wpp/internal/api/service"
 	"qrpay-wpp/internal/api/system"
+	"time"
 )
 
 type services struct {
-	wpp service.WhatsApp
+	wpp     service.WhatsApp
+	flow    service.Flow
+	conv    service.Conversation
+	consent service.Consent
+	webhook service.Webhook
+	outbox  service.Outbox
+	warmup  service.Warmup
+	recon   service.Reconciler
+	cluster service.Cluster
+	auth    service.Auth
+	pairing service.Pairing
+	health  service.Health
 }
 
-func (s *Server) createServices(wppSystem system.WhatsAppSystem) {
-	s.services.wpp = service.NewWhatsApp(s.db, s.repos.wpp, wppSystem)
+func (s *Server) createServices(wppSystem system.WhatsAppSystem) error {
+	nc := configs.Get().Cluster
+	host, _ := os.Hostname()
+	nodeID := nc.NodeID
+	if nodeID == "" {
+		nodeID = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	address := nc.Address
+	if address == "" {
+		address = fmt.Sprintf("%s:%d", host, configs.Get().Server.Port)
+	}
+
+	bc := configs.Get().Bus
+	if bc.Driver == "postgres" {
+		b, err := bus.NewPostgres(s.context, s.db, bc.Channel, nodeID)
+		if err != nil {
+			return fmt.Errorf("unable to start event bus: %v", err)
+		}
+		s.bus = b
+	} else {
+		s.bus = bus.NewMemory(nodeID)
+	}
+
+	c := configs.Get().Flow
+	definitions, err := flow.Load(c.DefinitionsPath)
+	if err != nil {
+		return fmt.Errorf("unable to load flow definitions: %v", err)
+	}
+	engine := flow.NewEngine(definitions, s.hooks)
+	s.services.flow = service.NewFlow(s.db, s.repos.flow, engine, time.Duration(c.SessionTTL)*time.Second)
+	s.services.conv = service.NewConversation(s.db, s.repos.conv, s.bus)
+	cc := configs.Get().Consent
+	s.services.consent = service.NewConsent(s.db, s.repos.consent, s.repos.suppression, cc.OptOutKeywords, cc.OptOutReply)
+	wc := configs.Get().Webhook
+	s.services.webhook = service.NewWebhook(s.db, s.repos.webhook, s.repos.delivery, s.repos.deadLetter, service.WebhookOptions{
+		MaxAttempts: wc.MaxAttempts,
+		Timeout:     time.Duration(wc.Timeout) * time.Second,
+		Backoff:     time.Duration(wc.Backoff) * time.Second,
+	})
+	s.services.cluster = service.NewCluster(s.db, s.repos.lease, s.repos.node, nodeID, address, time.Duration(nc.LeaseTTL)*time.Second)
+	oc := configs.Get().Outbox
+	s.services.warmup = service.NewWarmup(s.db, s.repos.wpp, s.repos.outbox, configs.Get().Warmup)
+	s.services.outbox = service.NewOutbox(s.db, s.repos.outbox, wppSystem, s.services.warmup, s.services.cluster, service.OutboxOptions{
+		MaxAttempts: oc.MaxAttempts,
+		Backoff:     time.Duration(oc.Backoff) * time.Second,
+	})
+	s.services.recon = service.NewReconciler(s.db, s.repos.wpp, wppSystem, s.services.cluster, time.Duration(configs.Get().Reconcile.Interval)*time.Second, time.Duration(nc.LeaseTTL)*time.Second)
+	s.services.pairing, err = service.NewPairing(configs.Get().Pairing, configs.Get().Gateway.Enabled)
+	if err != nil {
+		return err
+	}
+	s.services.auth, err = service.NewAuth(s.db, s.repos.apiKey, configs.Get().Auth, s.services.pairing)
+	if err != nil {
+		return err
+	}
+	s.services.health = service.NewHealth(s.db, s.repos.wpp, wppSystem,
+		proto.WhatsAppService_ServiceDesc.ServiceName,
+		proto.ConversationService_ServiceDesc.ServiceName,
+		proto.ConsentService_ServiceDesc.ServiceName,
+		proto.WebhookService_ServiceDesc.ServiceName,
+		proto.AdminService_ServiceDesc.ServiceName,
+	)
+	s.services.wpp = service.NewWhatsApp(s.db, s.repos.wpp, s.repos.ban, wppSystem, s.services.flow, s.services.conv, s.services.consent, s.services.webhook, s.services.outbox, s.services.cluster, s.bus)
+	return nil
 }
@@ -2,34 +2,259 @@ package system
 
 import (
 	"go.mau.fi/whatsmeow"
+	"sync"
+	"time"
 )
 
-type Connections map[string]*Connection
+type State string
 
-func (c Connections) Get(accountUUID string) *Connection {
-	return c[accountUUID]
+const (
+	StateNew          State = "new"
+	StatePairing      State = "pairing"
+	StateConnecting   State = "connecting"
+	StateConnected    State = "connected"
+	StateDisconnected State = "disconnected"
+	StateBanned       State = "banned"
+	StateLoggedOut    State = "logged_out"
+	StateReplaced     State = "replaced"
+)
+
+// transitions lists the states each state may move to. Logged out and replaced
+// connections are final, connecting again requires a new connection.
+var transitions = map[State][]State{
+	StateNew:          {StatePairing, StateConnecting, StateLoggedOut},
+	StatePairing:      {StatePairing, StateConnecting, StateConnected, StateDisconnected, StateLoggedOut, StateReplaced},
+	StateConnecting:   {StatePairing, StateConnected, StateDisconnected, StateBanned, StateLoggedOut, StateReplaced},
+	StateConnected:    {StateConnecting, StateDisconnected, StateBanned, StateLoggedOut, StateReplaced},
+	StateDisconnected: {StatePairing, StateConnecting, StateConnected, StateBanned, StateLoggedOut, StateReplaced},
+	StateBanned:       {StateConnecting, StateDisconnected, StateLoggedOut, StateReplaced},
+	StateLoggedOut:    {},
+	StateReplaced:     {},
+}
+
+// final reports whether the connection stopped for good and must be replaced to connect again.
+func (s State) final() bool {
+	return s == StateLoggedOut || s == StateReplaced
 }
-func (c Connections) Set(accountUUID string, connection *Connection) {
-	if accountUUID != "" && connection != nil {
-		c[accountUUID] = connection
+
+func canTransition(from State, to State) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
 	}
+	return false
 }
 
-func (c Connections) Remove(accountUUID string) {
-	delete(c, accountUUID)
+type StateChange struct {
+	AccountUUID string
+	From        State
+	To          State
+	At          time.Time
 }
 
 type Connection struct {
 	AccountUUID string
 	Client      *whatsmeow.Client
-	QRCode      string
-	Connected   bool
-	Paired      bool
+
+	mu        sync.RWMutex
+	state     State
+	qrCode    string
+	registry  *Registry
+	attempts  int
+	nextRetry time.Time
+	lastError string
+	retry     *time.Timer
 }
 
 func NewConnection(accountUUID string, client *whatsmeow.Client) *Connection {
 	return &Connection{
 		AccountUUID: accountUUID,
 		Client:      client,
+		state:       StateNew,
+	}
+}
+
+func (c *Connection) State() State {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state
+}
+
+func (c *Connection) QRCode() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.qrCode
+}
+
+func (c *Connection) SetQRCode(code string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.qrCode = code
+}
+
+func (c *Connection) Attempts() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.attempts
+}
+
+func (c *Connection) Status() Status {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	st := Status{
+		State:     c.state,
+		Attempts:  c.attempts,
+		NextRetry: c.nextRetry,
+		LastError: c.lastError,
+	}
+	if c.Client != nil && c.Client.Store.ID != nil {
+		st.Phone = c.Client.Store.ID.User
+	}
+	return st
+}
+
+// scheduleRetry runs fn after delay, replacing any retry already pending.
+func (c *Connection) scheduleRetry(delay time.Duration, reason string, fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.retry != nil {
+		c.retry.Stop()
+	}
+	c.attempts++
+	c.nextRetry = time.Now().UTC().Add(delay)
+	if reason != "" {
+		c.lastError = reason
+	}
+	c.retry = time.AfterFunc(delay, fn)
+}
+
+func (c *Connection) resetRetry() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.retry != nil {
+		c.retry.Stop()
+		c.retry = nil
+	}
+	c.attempts = 0
+	c.nextRetry = time.Time{}
+	c.lastError = ""
+}
+
+func (c *Connection) cancelRetry(reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.retry != nil {
+		c.retry.Stop()
+		c.retry = nil
+	}
+	c.nextRetry = time.Time{}
+	c.lastError = reason
+}
+
+// Transition atomically moves the connection to the given state when allowed
+// from the current one, notifying the registry subscribers of the change.
+func (c *Connection) Transition(to State) bool {
+	c.mu.Lock()
+	from := c.state
+	if !canTransition(from, to) {
+		c.mu.Unlock()
+		return false
+	}
+	c.state = to
+	if to != StatePairing {
+		c.qrCode = ""
+	}
+	registry := c.registry
+	c.mu.Unlock()
+
+	if registry != nil {
+		registry.notify(StateChange{
+			AccountUUID: c.AccountUUID,
+			From:        from,
+			To:          to,
+			At:          time.Now().UTC(),
+		})
+	}
+	return true
+}
+
+// Registry keeps the live connection of each account and is safe for concurrent use.
+type Registry struct {
+	mu          sync.RWMutex
+	connections map[string]*Connection
+
+	subMu       sync.Mutex
+	subscribers map[chan StateChange]struct{}
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		connections: map[string]*Connection{},
+		subscribers: map[chan StateChange]struct{}{},
+	}
+}
+
+func (r *Registry) Get(accountUUID string) *Connection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.connections[accountUUID]
+}
+
+// Add registers the connection unless the account already has one, which is returned instead.
+func (r *Registry) Add(connection *Connection) (*Connection, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.connections[connection.AccountUUID]; ok {
+		return existing, false
+	}
+	connection.mu.Lock()
+	connection.registry = r
+	connection.mu.Unlock()
+	r.connections[connection.AccountUUID] = connection
+	return connection, true
+}
+
+// Remove unregisters the connection if it is still the one registered for its account.
+func (r *Registry) Remove(connection *Connection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.connections[connection.AccountUUID] == connection {
+		delete(r.connections, connection.AccountUUID)
+	}
+}
+
+func (r *Registry) All() []*Connection {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	connections := make([]*Connection, 0, len(r.connections))
+	for _, c := range r.connections {
+		connections = append(connections, c)
+	}
+	return connections
+}
+
+// Subscribe returns a channel receiving every state change until the returned
+// function is called. Changes are dropped for subscribers that fall behind.
+func (r *Registry) Subscribe() (<-chan StateChange, func()) {
+	ch := make(chan StateChange, 64)
+	r.subMu.Lock()
+	r.subscribers[ch] = struct{}{}
+	r.subMu.Unlock()
+	return ch, func() {
+		r.subMu.Lock()
+		defer r.subMu.Unlock()
+		delete(r.subscribers, ch)
+	}
+}
+
+func (r *Registry) notify(change StateChange) {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+	for ch := range r.subscribers {
+		select {
+		case ch <- change:
+		default:
+		}
 	}
 }
@@ -0,0 +1,244 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"go.mau.fi/whatsmeow/types/events"
+	"qrpay-wpp/configs"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Overflow policies applied when the queue of an account is full.
+const (
+	OverflowBlock      = "block"
+	OverflowDropNewest = "drop_newest"
+	OverflowDropOldest = "drop_oldest"
+)
+
+const (
+	defaultDispatchWorkers = 8
+	defaultDispatchQueue   = 256
+)
+
+// DispatchStats are the counters of the event dispatcher since startup.
+type DispatchStats struct {
+	Workers     int
+	Queued      int
+	MaxQueued   int
+	Accounts    int
+	Processed   uint64
+	Dropped     uint64
+	Panics      uint64
+	LastLatency time.Duration
+	MaxLatency  time.Duration
+}
+
+type dispatchJob struct {
+	accountUUID string
+	evt         any
+	handler     func(string, any)
+	queuedAt    time.Time
+}
+
+type accountQueue struct {
+	accountUUID string
+	jobs        []dispatchJob
+	scheduled   bool
+}
+
+// dispatcher runs event handlers off the whatsmeow event goroutine. Events of
+// an account are handled in order, one at a time, while a pool of workers
+// serves the accounts in turns. Handler panics are recovered and logged.
+type dispatcher struct {
+	workers  int
+	size     int
+	overflow string
+
+	mu     sync.Mutex
+	ready  *sync.Cond
+	space  *sync.Cond
+	queues map[string]*accountQueue
+	turns  []*accountQueue
+	closed bool
+	stats  DispatchStats
+	wg     sync.WaitGroup
+}
+
+func newDispatcher(config configs.Dispatch) *dispatcher {
+	d := &dispatcher{
+		workers:  config.Workers,
+		size:     config.QueueSize,
+		overflow: config.Overflow,
+		queues:   map[string]*accountQueue{},
+	}
+	if d.workers < 1 {
+		d.workers = defaultDispatchWorkers
+	}
+	if d.size < 1 {
+		d.size = defaultDispatchQueue
+	}
+	if d.overflow == "" {
+		d.overflow = OverflowBlock
+	}
+	d.ready = sync.NewCond(&d.mu)
+	d.space = sync.NewCond(&d.mu)
+	d.stats.Workers = d.workers
+	for i := 0; i < d.workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+	return d
+}
+
+// lifecycle reports whether the event changes the state of the session, which
+// the services persist, so that it is never dropped.
+func lifecycle(evt any) bool {
+	switch evt.(type) {
+	case *events.PairSuccess, *events.Connected, *events.Disconnected, *events.StreamReplaced,
+		*events.LoggedOut, *events.TemporaryBan, *events.ConnectFailure:
+		return true
+	}
+	return false
+}
+
+// dropOldest drops the oldest event of the queue that is not a lifecycle one,
+// reporting whether there was one.
+func (d *dispatcher) dropOldest(q *accountQueue) bool {
+	for i, job := range q.jobs {
+		if !lifecycle(job.evt) {
+			q.jobs = append(q.jobs[:i], q.jobs[i+1:]...)
+			d.stats.Queued--
+			d.stats.Dropped++
+			return true
+		}
+	}
+	return false
+}
+
+// Dispatch queues the event for the handler. When the account queue is full the
+// event is dropped, the oldest one is dropped, or the caller waits for room,
+// depending on the overflow policy. Lifecycle events are never dropped, they
+// take the place of an older event or wait for room.
+func (d *dispatcher) Dispatch(accountUUID string, evt any, handler func(string, any)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	q := d.queues[accountUUID]
+	if q == nil {
+		q = &accountQueue{accountUUID: accountUUID}
+		d.queues[accountUUID] = q
+	}
+	for len(q.jobs) >= d.size && !d.closed {
+		switch {
+		case d.overflow == OverflowDropNewest && !lifecycle(evt):
+			d.stats.Dropped++
+			return
+		case d.overflow != OverflowBlock && d.dropOldest(q):
+		default:
+			d.space.Wait()
+		}
+	}
+	if d.closed {
+		d.stats.Dropped++
+		return
+	}
+	q.jobs = append(q.jobs, dispatchJob{
+		accountUUID: accountUUID,
+		evt:         evt,
+		handler:     handler,
+		queuedAt:    time.Now(),
+	})
+	d.stats.Queued++
+	if d.stats.Queued > d.stats.MaxQueued {
+		d.stats.MaxQueued = d.stats.Queued
+	}
+	if !q.scheduled {
+		q.scheduled = true
+		d.turns = append(d.turns, q)
+		d.ready.Signal()
+	}
+}
+
+func (d *dispatcher) work() {
+	defer d.wg.Done()
+	for {
+		d.mu.Lock()
+		for len(d.turns) == 0 && !d.closed {
+			d.ready.Wait()
+		}
+		if len(d.turns) == 0 {
+			d.mu.Unlock()
+			return
+		}
+		q := d.turns[0]
+		d.turns = d.turns[1:]
+		job := q.jobs[0]
+		q.jobs = q.jobs[1:]
+		d.stats.Queued--
+		latency := time.Since(job.queuedAt)
+		d.stats.LastLatency = latency
+		if latency > d.stats.MaxLatency {
+			d.stats.MaxLatency = latency
+		}
+		d.space.Broadcast()
+		d.mu.Unlock()
+
+		d.run(job)
+
+		// The account takes another turn after the others waiting, which keeps
+		// a busy account from starving them while preserving its order.
+		d.mu.Lock()
+		d.stats.Processed++
+		if len(q.jobs) > 0 {
+			d.turns = append(d.turns, q)
+			d.ready.Signal()
+		} else {
+			q.scheduled = false
+			delete(d.queues, q.accountUUID)
+		}
+		d.mu.Unlock()
+	}
+}
+
+func (d *dispatcher) run(job dispatchJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			d.mu.Lock()
+			d.stats.Panics++
+			d.mu.Unlock()
+			fmt.Printf("Event handler panic for %s on %T: %v\n%s\n", job.accountUUID, job.evt, r, debug.Stack())
+		}
+	}()
+	job.handler(job.accountUUID, job.evt)
+}
+
+func (d *dispatcher) Stats() DispatchStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	stats := d.stats
+	stats.Accounts = len(d.queues)
+	return stats
+}
+
+// Close stops accepting events and waits for the queued ones to be handled,
+// until ctx is done.
+func (d *dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	d.closed = true
+	d.ready.Broadcast()
+	d.space.Broadcast()
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,64 @@
+package system
+
+import (
+	"context"
+	"go.mau.fi/whatsmeow/types/events"
+	"qrpay-wpp/configs"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDispatcherKeepsLifecycleEvents(t *testing.T) {
+	for _, overflow := range []string{OverflowDropNewest, OverflowDropOldest} {
+		t.Run(overflow, func(t *testing.T) {
+			d := newDispatcher(configs.Dispatch{Workers: 1, QueueSize: 2, Overflow: overflow})
+			started := make(chan struct{})
+			release := make(chan struct{})
+			var mu sync.Mutex
+			var handled []any
+			first := &events.Message{}
+			handler := func(_ string, evt any) {
+				if evt == first {
+					close(started)
+					<-release
+				}
+				mu.Lock()
+				handled = append(handled, evt)
+				mu.Unlock()
+			}
+
+			d.Dispatch("account", first, handler)
+			<-started
+			connected := &events.Connected{}
+			dropped := &events.Message{}
+			d.Dispatch("account", connected, handler)
+			d.Dispatch("account", dropped, handler)
+			// The queue is full, the lifecycle event replaces the message
+			loggedOut := &events.LoggedOut{}
+			d.Dispatch("account", loggedOut, handler)
+			close(release)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err := d.Close(ctx)
+			if err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			want := []any{first, connected, loggedOut}
+			if len(handled) != len(want) {
+				t.Fatalf("got %d events handled, want %d", len(handled), len(want))
+			}
+			for i := range want {
+				if handled[i] != want[i] {
+					t.Errorf("event %d: got %T, want %T", i, handled[i], want[i])
+				}
+			}
+			if st := d.Stats(); st.Dropped != 1 {
+				t.Errorf("got %d events dropped, want 1", st.Dropped)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package system
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"math/rand"
+	"qrpay-wpp/configs"
+	"sync"
+	"time"
+)
+
+// RetryAfter builds a status error carrying a RetryInfo detail with the given delay.
+func RetryAfter(code codes.Code, msg string, delay time.Duration) error {
+	st := status.New(code, msg)
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(perMinute float64, burst int, now time.Time) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   perMinute / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+// wait returns how long until a token is available. Buckets without rate never limit.
+func (b *tokenBucket) wait(now time.Time) time.Duration {
+	if b == nil || b.rate <= 0 {
+		return 0
+	}
+	b.refill(now)
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+func (b *tokenBucket) take() {
+	if b != nil && b.rate > 0 {
+		b.tokens--
+	}
+}
+
+func (b *tokenBucket) give() {
+	if b != nil && b.rate > 0 && b.tokens < b.burst {
+		b.tokens++
+	}
+}
+
+type accountLimiter struct {
+	mu         sync.Mutex
+	config     configs.RateLimit
+	bucket     *tokenBucket
+	recipients map[string]*tokenBucket
+	day        string
+	sent       int
+	next       time.Time
+}
+
+// reserve accounts for a message to recipient and returns how long the sender
+// must pace before sending it, or a ResourceExhausted error when a limit is hit.
+// Days are counted in UTC, as the warm-up does.
+func (l *accountLimiter) reserve(recipient string, now time.Time) (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c := l.config
+
+	now = now.UTC()
+	err := l.dailyCap(now)
+	if err != nil {
+		return 0, err
+	}
+
+	if wait := l.bucket.wait(now); wait > 0 {
+		return 0, RetryAfter(codes.ResourceExhausted, "account rate limit exceeded", wait)
+	}
+	bucket, ok := l.recipients[recipient]
+	if !ok && c.RecipientPerMinute > 0 {
+		bucket = newTokenBucket(c.RecipientPerMinute, c.RecipientBurst, now)
+		l.recipients[recipient] = bucket
+	}
+	if wait := bucket.wait(now); wait > 0 {
+		return 0, RetryAfter(codes.ResourceExhausted, "recipient rate limit exceeded", wait)
+	}
+	l.bucket.take()
+	bucket.take()
+	l.sent++
+
+	var delay time.Duration
+	if l.next.After(now) {
+		delay = l.next.Sub(now)
+	}
+	pace := time.Duration(c.MinDelay) * time.Millisecond
+	if c.MaxDelay > c.MinDelay {
+		pace += time.Duration(rand.Int63n(int64(c.MaxDelay-c.MinDelay))) * time.Millisecond
+	}
+	l.next = now.Add(delay + pace)
+	return delay, nil
+}
+
+// dailyCap starts a new day when the date changes and returns a ResourceExhausted
+// error, retryable on the next day, once the daily cap is reached. It must be
+// called with l.mu held.
+func (l *accountLimiter) dailyCap(now time.Time) error {
+	day := now.Format(time.DateOnly)
+	if l.day != day {
+		l.day = day
+		l.sent = 0
+		l.recipients = map[string]*tokenBucket{}
+	}
+	if l.config.DailyCap > 0 && l.sent >= l.config.DailyCap {
+		year, month, d := now.Date()
+		tomorrow := time.Date(year, month, d+1, 0, 0, 0, 0, now.Location())
+		return RetryAfter(codes.ResourceExhausted, "daily message cap reached", tomorrow.Sub(now))
+	}
+	return nil
+}
+
+// quota returns the error reserve would fail with because of the daily cap,
+// without reserving anything.
+func (l *accountLimiter) quota(now time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dailyCap(now.UTC())
+}
+
+// refund gives back what reserve charged for a message that was not sent.
+func (l *accountLimiter) refund(recipient string, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.day != now.UTC().Format(time.DateOnly) {
+		return
+	}
+	l.bucket.give()
+	l.recipients[recipient].give()
+	if l.sent > 0 {
+		l.sent--
+	}
+}
+
+// typing returns how long to show the typing indicator for a text.
+func (l *accountLimiter) typing(text string) time.Duration {
+	c := l.config
+	if c.TypingCharsPerSecond <= 0 {
+		return 0
+	}
+	d := time.Duration(float64(len([]rune(text))) / float64(c.TypingCharsPerSecond) * float64(time.Second))
+	limit := time.Duration(c.MaxTyping) * time.Millisecond
+	if limit > 0 && d > limit {
+		d = limit
+	}
+	return d
+}
+
+type limiter struct {
+	mu       sync.Mutex
+	config   configs.RateLimits
+	accounts map[string]*accountLimiter
+}
+
+func newLimiter(config configs.RateLimits) *limiter {
+	return &limiter{
+		config:   config,
+		accounts: map[string]*accountLimiter{},
+	}
+}
+
+// get returns the limiter of the account, configured by its override when there is one.
+func (l *limiter) get(accountUUID string) *accountLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	a, ok := l.accounts[accountUUID]
+	if ok {
+		return a
+	}
+	c, ok := l.config.Accounts[accountUUID]
+	if !ok {
+		c = l.config.Default
+	}
+	a = &accountLimiter{
+		config:     c,
+		recipients: map[string]*tokenBucket{},
+	}
+	if c.PerMinute > 0 {
+		a.bucket = newTokenBucket(c.PerMinute, c.Burst, time.Now())
+	}
+	l.accounts[accountUUID] = a
+	return a
+}
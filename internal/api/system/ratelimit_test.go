@@ -0,0 +1,47 @@
+package system
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"qrpay-wpp/configs"
+	"testing"
+	"time"
+)
+
+func TestQuotaReportsDailyCap(t *testing.T) {
+	l := newLimiter(configs.RateLimits{Default: configs.RateLimit{DailyCap: 2}}).get("account")
+	now := time.Date(2026, 10, 19, 22, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 2; i++ {
+		if err := l.quota(now); err != nil {
+			t.Fatalf("message %d: unexpected quota error: %v", i+1, err)
+		}
+		if _, err := l.reserve("5511912345678", now); err != nil {
+			t.Fatalf("message %d: unexpected reserve error: %v", i+1, err)
+		}
+	}
+
+	err := l.quota(now)
+	st, _ := status.FromError(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Fatalf("got %v, want ResourceExhausted", err)
+	}
+	var delay time.Duration
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			delay = info.RetryDelay.AsDuration()
+		}
+	}
+	if delay != 2*time.Hour {
+		t.Errorf("got retry delay %v, want the 2h left until midnight UTC", delay)
+	}
+
+	l.refund("5511912345678", now)
+	if err := l.quota(now); err != nil {
+		t.Errorf("unexpected quota error after a refund: %v", err)
+	}
+	if err := l.quota(now.Add(3 * time.Hour)); err != nil {
+		t.Errorf("unexpected quota error on the next day: %v", err)
+	}
+}
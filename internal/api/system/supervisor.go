@@ -0,0 +1,107 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types/events"
+	"math"
+	"math/rand"
+	"qrpay-wpp/configs"
+	"time"
+)
+
+// Status is a snapshot of an account connection and its reconnect supervision.
+type Status struct {
+	State     State
+	Phone     string
+	Attempts  int
+	NextRetry time.Time
+	LastError string
+}
+
+// supervisor reconnects connections after they drop. Transient disconnects are
+// retried with exponential backoff and jitter, temporary bans wait until the
+// ban expires and logouts stop the connection until the account pairs again.
+// Sessions taken over by another client are stopped too, as taking them back
+// would only replace the other side in turn.
+type supervisor struct {
+	config  configs.Reconnect
+	refresh func()
+}
+
+func newSupervisor(config configs.Reconnect, refresh func()) *supervisor {
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = 1000
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		config.MaxBackoff = config.InitialBackoff
+	}
+	if config.Multiplier < 1 {
+		config.Multiplier = 2
+	}
+	return &supervisor{config: config, refresh: refresh}
+}
+
+// backoff returns the delay before the given reconnect attempt, randomized by the configured jitter.
+func (s *supervisor) backoff(attempt int) time.Duration {
+	initial := float64(s.config.InitialBackoff)
+	delay := math.Min(initial*math.Pow(s.config.Multiplier, float64(attempt)), float64(s.config.MaxBackoff))
+	if s.config.Jitter > 0 {
+		delay += delay * s.config.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay) * time.Millisecond
+}
+
+func (s *supervisor) handle(connection *Connection, evt any) {
+	// Logging out deletes the device, so it is handled before the pairing check.
+	if v, ok := evt.(*events.LoggedOut); ok {
+		s.stop(connection, StateLoggedOut, fmt.Sprintf("logged out: %v", v.Reason))
+		return
+	}
+	// Unpaired clients are restarted by the QR routine when pairing times out.
+	if connection.Client.Store.ID == nil {
+		return
+	}
+	switch v := evt.(type) {
+	case *events.Connected:
+		connection.resetRetry()
+	case *events.StreamReplaced:
+		s.stop(connection, StateReplaced, "stream replaced by another client")
+	case *events.Disconnected:
+		s.schedule(connection, s.backoff(connection.Attempts()), "")
+	case *events.ConnectFailure:
+		s.schedule(connection, s.backoff(connection.Attempts()), fmt.Sprintf("connect failure %d", v.Reason))
+	case *events.TemporaryBan:
+		delay := v.Expire
+		if delay <= 0 {
+			delay = time.Duration(s.config.MaxBackoff) * time.Millisecond
+		}
+		s.schedule(connection, delay, v.String())
+	}
+}
+
+// schedule reconnects the same client after delay, unless the connection was stopped meanwhile.
+func (s *supervisor) schedule(connection *Connection, delay time.Duration, reason string) {
+	connection.scheduleRetry(delay, reason, func() {
+		if connection.State().final() {
+			return
+		}
+		connection.Transition(StateConnecting)
+		err := connection.Client.Connect()
+		if err == nil || errors.Is(err, whatsmeow.ErrAlreadyConnected) {
+			return
+		}
+		connection.Transition(StateDisconnected)
+		s.schedule(connection, s.backoff(connection.Attempts()), err.Error())
+	})
+}
+
+// stop disconnects a logged out or replaced connection. It stays registered in
+// its final state so its status remains visible until the account connects again.
+func (s *supervisor) stop(connection *Connection, state State, reason string) {
+	connection.cancelRetry(reason)
+	connection.Transition(state)
+	connection.Client.Disconnect()
+	s.refresh()
+}
@@ -2,6 +2,7 @@ package system
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	_ "github.com/lib/pq"
 	"go.mau.fi/whatsmeow"
@@ -13,26 +14,48 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"net/http"
 	"qrpay-wpp/configs"
 	"qrpay-wpp/internal/common"
+	"sync"
+	"time"
 )
 
 type WhatsAppSystem interface {
 	Connect(ctx context.Context, accountId string, phone string, eventHandler func(string, any)) error
 	GetQRCode(uuid string) (string, error)
-	SendMessage(ctx context.Context, uuid string, to string, text string, media []byte) error
+	SendMessage(ctx context.Context, uuid string, to string, text string, media []byte) (string, error)
+	Quota(uuid string) error
+	State(uuid string) State
+	Status(uuid string) Status
+	Subscribe() (<-chan StateChange, func())
+	PairedPhones() []string
+	Disconnect(uuid string)
+	DispatchStats() DispatchStats
+	Ping(ctx context.Context) error
+	Close(ctx context.Context) error
 }
 
 type whatsAppSystem struct {
+	db          *sql.DB
 	container   *sqlstore.Container
+	devicesMu   sync.RWMutex
 	devices     []*store.Device
-	connections Connections
+	connections *Registry
+	limiter     *limiter
+	supervisor  *supervisor
+	dispatcher  *dispatcher
 }
 
 func New() (WhatsAppSystem, error) {
 	wc := configs.Get().Database
 	url := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=disable", wc.Username, wc.Password, wc.Host, wc.Port, wc.Name)
-	container, err := sqlstore.New("postgres", url, nil)
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+	container := sqlstore.NewWithDB(db, "postgres", nil)
+	err = container.Upgrade()
 	if err != nil {
 		return nil, err
 	}
@@ -42,17 +65,52 @@ func New() (WhatsAppSystem, error) {
 		return nil, err
 	}
 
-	return &whatsAppSystem{
-		container: container,
-		devices:   devices,
-	}, nil
+	s := &whatsAppSystem{
+		db:          db,
+		container:   container,
+		devices:     devices,
+		connections: NewRegistry(),
+		limiter:     newLimiter(configs.Get().RateLimit),
+	}
+	s.supervisor = newSupervisor(configs.Get().Reconnect, s.refreshDevices)
+	s.dispatcher = newDispatcher(configs.Get().Dispatch)
+	return s, nil
+}
+
+// refreshDevices reloads the paired devices from the store, so that sessions
+// paired after startup can be reconnected by phone.
+func (s *whatsAppSystem) refreshDevices() {
+	devices, err := s.container.GetAllDevices()
+	if err != nil {
+		fmt.Printf("Unable to refresh devices: %v\n", err)
+		return
+	}
+	s.devicesMu.Lock()
+	s.devices = devices
+	s.devicesMu.Unlock()
+}
+
+// PairedPhones returns the phones of every device stored in the session store.
+func (s *whatsAppSystem) PairedPhones() []string {
+	s.refreshDevices()
+	s.devicesMu.RLock()
+	defer s.devicesMu.RUnlock()
+	phones := make([]string, 0, len(s.devices))
+	for _, d := range s.devices {
+		if d.ID != nil {
+			phones = append(phones, d.ID.User)
+		}
+	}
+	return phones
 }
 
 func (s *whatsAppSystem) getDevice(phone string) *store.Device {
 	var device *store.Device
+	s.devicesMu.RLock()
+	defer s.devicesMu.RUnlock()
 	if phone != "" {
 		for _, d := range s.devices {
-			if d.ID.User == phone {
+			if d.ID != nil && d.ID.User == phone {
 				device = d
 				break
 			}
@@ -64,65 +122,85 @@ func (s *whatsAppSystem) getDevice(phone string) *store.Device {
 	return device
 }
 
-func (s *whatsAppSystem) connectNewClient(ctx context.Context, connection *Connection) error {
+// connectNewClient starts pairing. The QR channel is bound to the connection
+// rather than to the request that asked for it, which ends before pairing does.
+func (s *whatsAppSystem) connectNewClient(connection *Connection) error {
 	client := connection.Client
-	qrChan, err := client.GetQRChannel(ctx)
+	qrChan, err := client.GetQRChannel(context.Background())
 	if err != nil {
 		return err
 	}
+	connection.Transition(StatePairing)
 	err = client.Connect()
 	if err != nil {
 		return err
 	}
-	go s.qrCodeRoutine(ctx, connection, qrChan)
+	go s.qrCodeRoutine(connection, qrChan)
 	return nil
 }
 
+func trackState(connection *Connection, evt any) {
+	switch evt.(type) {
+	case *events.PairSuccess:
+		connection.Transition(StateConnecting)
+	case *events.Connected:
+		connection.Transition(StateConnected)
+	case *events.Disconnected:
+		connection.Transition(StateDisconnected)
+	case *events.StreamReplaced:
+		connection.Transition(StateReplaced)
+	case *events.TemporaryBan:
+		connection.Transition(StateBanned)
+	case *events.LoggedOut:
+		connection.Transition(StateLoggedOut)
+	}
+}
+
 func (s *whatsAppSystem) Connect(ctx context.Context, accountUUID string, phone string, eventHandler func(string, any)) error {
-	existingConnection := s.connections.Get(accountUUID)
-	if existingConnection != nil {
-		return nil
+	if existing := s.connections.Get(accountUUID); existing != nil {
+		// Logged out and replaced connections are kept only for their status,
+		// connecting again replaces them.
+		if !existing.State().final() {
+			return nil
+		}
+		s.connections.Remove(existing)
 	}
 	device := s.getDevice(phone)
 	client := whatsmeow.NewClient(device, nil)
+	// Reconnects are handled by the supervisor, which keeps the device and handler.
+	client.EnableAutoReconnect = false
+	connection, added := s.connections.Add(NewConnection(accountUUID, client))
+	if !added {
+		return nil
+	}
 	client.AddEventHandler(func(evt any) {
-		eventHandler(accountUUID, evt)
-		switch evt.(type) {
-		case *events.Disconnected:
-			s.restart(accountUUID)
-		case *events.TemporaryBan:
-			s.restart(accountUUID)
-		case *events.LoggedOut:
-			s.restart(accountUUID)
+		trackState(connection, evt)
+		if _, ok := evt.(*events.PairSuccess); ok {
+			s.refreshDevices()
 		}
+		s.dispatcher.Dispatch(accountUUID, evt, eventHandler)
+		s.supervisor.handle(connection, evt)
 	})
-	connection := NewConnection(accountUUID, client)
-	s.connections.Set(accountUUID, connection)
 
 	if client.Store.ID != nil {
+		connection.Transition(StateConnecting)
 		err := client.Connect()
 		if err != nil {
+			connection.Transition(StateDisconnected)
+			s.connections.Remove(connection)
 			return err
 		}
 		return nil
 	}
-	err := s.connectNewClient(ctx, existingConnection)
+	err := s.connectNewClient(connection)
 	if err != nil {
+		s.connections.Remove(connection)
 		return err
 	}
 	return nil
 }
 
-func (s *whatsAppSystem) restart(accountUUID string) {
-	connection := s.connections.Get(accountUUID)
-	if connection == nil {
-		return
-	}
-	s.connections.Remove(accountUUID)
-	s.Connect(context.Background(), accountUUID, "", nil)
-}
-
-func (s *whatsAppSystem) qrCodeRoutine(ctx context.Context, connection *Connection, qrChan <-chan whatsmeow.QRChannelItem) {
+func (s *whatsAppSystem) qrCodeRoutine(connection *Connection, qrChan <-chan whatsmeow.QRChannelItem) {
 	var previousCode string
 	timeout := false
 	for evt := range qrChan {
@@ -136,11 +214,11 @@ func (s *whatsAppSystem) qrCodeRoutine(ctx context.Context, connection *Connecti
 		if previousCode == newQRCode {
 			continue
 		}
-		connection.QRCode = newQRCode
+		connection.SetQRCode(newQRCode)
 		previousCode = newQRCode
 	}
 	if timeout {
-		err := s.connectNewClient(ctx, connection)
+		err := s.connectNewClient(connection)
 		if err != nil {
 			return
 		}
@@ -152,28 +230,141 @@ func (s *whatsAppSystem) GetQRCode(accountUUID string) (string, error) {
 	if connection == nil {
 		return "", status.Error(codes.NotFound, "connection not found")
 	}
-	return connection.QRCode, nil
+	return connection.QRCode(), nil
+}
+
+// State returns the state of the account connection, StateNew when there is none.
+func (s *whatsAppSystem) State(accountUUID string) State {
+	connection := s.connections.Get(accountUUID)
+	if connection == nil {
+		return StateNew
+	}
+	return connection.State()
+}
+
+// Disconnect closes the connection of the account and forgets it, keeping its device paired.
+func (s *whatsAppSystem) Disconnect(accountUUID string) {
+	connection := s.connections.Get(accountUUID)
+	if connection == nil {
+		return
+	}
+	connection.cancelRetry("disconnected")
+	s.connections.Remove(connection)
+	connection.Client.Disconnect()
+	connection.Transition(StateDisconnected)
+}
+
+// Status returns the connection status of the account, in StateNew when there is no connection.
+func (s *whatsAppSystem) Status(accountUUID string) Status {
+	connection := s.connections.Get(accountUUID)
+	if connection == nil {
+		return Status{State: StateNew}
+	}
+	return connection.Status()
+}
+
+func (s *whatsAppSystem) DispatchStats() DispatchStats {
+	return s.dispatcher.Stats()
+}
+
+// Close disconnects every client, waits for their queued events to be handled
+// until ctx is done and closes the session store.
+func (s *whatsAppSystem) Close(ctx context.Context) error {
+	for _, connection := range s.connections.All() {
+		s.Disconnect(connection.AccountUUID)
+	}
+	err := s.dispatcher.Close(ctx)
+	if err != nil {
+		fmt.Printf("Unable to handle every queued event: %v\n", err)
+	}
+	return s.db.Close()
+}
+
+// Ping checks that the session store is reachable.
+func (s *whatsAppSystem) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+func (s *whatsAppSystem) Subscribe() (<-chan StateChange, func()) {
+	return s.connections.Subscribe()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// simulateTyping shows the typing indicator to the recipient for the given duration.
+func (s *whatsAppSystem) simulateTyping(ctx context.Context, client *whatsmeow.Client, to types.JID, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	err := client.SendChatPresence(to, types.ChatPresenceComposing, types.ChatPresenceMediaText)
+	if err != nil {
+		return
+	}
+	_ = sleep(ctx, d)
+	_ = client.SendChatPresence(to, types.ChatPresencePaused, types.ChatPresenceMediaText)
+}
+
+// Quota returns a ResourceExhausted error once the account reached its daily cap.
+func (s *whatsAppSystem) Quota(accountUUID string) error {
+	return s.limiter.get(accountUUID).quota(time.Now())
 }
 
-func (s *whatsAppSystem) SendMessage(ctx context.Context, accountUUID string, phone string, msg string, media []byte) error {
+func (s *whatsAppSystem) SendMessage(ctx context.Context, accountUUID string, phone string, msg string, media []byte) (string, error) {
 	connection := s.connections.Get(accountUUID)
 	if connection == nil {
-		return status.Error(codes.NotFound, "connection not found")
+		return "", status.Error(codes.NotFound, "connection not found")
+	}
+	st := connection.Status()
+	if st.State == StateBanned && !st.NextRetry.IsZero() {
+		return "", RetryAfter(codes.FailedPrecondition, "account is temporarily banned", time.Until(st.NextRetry))
+	}
+	if st.State != StateConnected {
+		return "", status.Error(codes.Unavailable, "connection is "+string(st.State))
 	}
 
 	client := connection.Client
 	sanitizedPhone := common.SanitizePhone(phone)
 	to := types.NewJID(sanitizedPhone, types.DefaultUserServer)
 
+	limiter := s.limiter.get(accountUUID)
+	delay, err := limiter.reserve(sanitizedPhone, time.Now())
+	if err != nil {
+		return "", err
+	}
+	// Messages that do not go out do not count against the limits
+	sent := false
+	defer func() {
+		if !sent {
+			limiter.refund(sanitizedPhone, time.Now())
+		}
+	}()
+	err = sleep(ctx, delay)
+	if err != nil {
+		return "", err
+	}
+	s.simulateTyping(ctx, client, to, limiter.typing(msg))
+
 	var message *waProto.Message
-	if media != nil {
+	if len(media) > 0 {
 		res, err := client.Upload(ctx, media, whatsmeow.MediaImage)
 		if err != nil {
-			return err
+			return "", err
 		}
 		imageMsg := &waProto.ImageMessage{
 			Caption:       proto.String(msg),
-			Mimetype:      proto.String("image/png"),
+			Mimetype:      proto.String(http.DetectContentType(media)),
 			Url:           &res.URL,
 			DirectPath:    &res.DirectPath,
 			MediaKey:      res.MediaKey,
@@ -189,9 +380,10 @@ func (s *whatsAppSystem) SendMessage(ctx context.Context, accountUUID string, ph
 			Conversation: proto.String(msg),
 		}
 	}
-	_, err := client.SendMessage(ctx, to, message)
+	res, err := client.SendMessage(ctx, to, message)
 	if err != nil {
-		return err
+		return "", err
 	}
-	return nil
+	sent = true
+	return res.ID, nil
 }
@@ -1,6 +1,12 @@
 package common
 
+import "strings"
+
+// SanitizePhone turns a phone into the digits whatsmeow expects in a JID,
+// dropping the leading "+" of the E.164 form and the ninth digit of Brazilian
+// mobile numbers.
 func SanitizePhone(phone string) string {
+	phone = strings.TrimPrefix(phone, "+")
 	if len(phone) == 13 && phone[4] == '9' {
 		phone = phone[0:4] + phone[5:]
 	}
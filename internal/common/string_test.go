@@ -0,0 +1,22 @@
+package common
+
+import "testing"
+
+func TestSanitizePhone(t *testing.T) {
+	tests := []struct {
+		phone string
+		want  string
+	}{
+		{"5511912345678", "551112345678"},
+		{"+5511912345678", "551112345678"},
+		{"551112345678", "551112345678"},
+		{"+551112345678", "551112345678"},
+		{"14155550123", "14155550123"},
+		{"+14155550123", "14155550123"},
+	}
+	for _, tt := range tests {
+		if got := SanitizePhone(tt.phone); got != tt.want {
+			t.Errorf("SanitizePhone(%q) = %q, want %q", tt.phone, got, tt.want)
+		}
+	}
+}
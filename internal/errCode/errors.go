@@ -3,6 +3,8 @@ package errCode
 import (
 	errs "github.com/cristiancll/go-errors"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
 )
 
 const (
@@ -15,12 +17,17 @@ const (
 	Unauthorized
 	Unauthenticated
 	InvalidArgument
+	Suppressed
+	Banned
+	NotOwner
 )
 
 func ToGRPCCode(code errs.Code) codes.Code {
 	switch code {
 	case Internal:
 		return codes.Internal
+	case InvalidArgument:
+		return codes.InvalidArgument
 	case NotFound:
 		return codes.NotFound
 	case NotChanged:
@@ -33,7 +40,82 @@ func ToGRPCCode(code errs.Code) codes.Code {
 		return codes.Unauthenticated
 	case Unauthenticated:
 		return codes.Unauthenticated
+	case Suppressed:
+		return codes.FailedPrecondition
+	case Banned:
+		return codes.FailedPrecondition
+	case NotOwner:
+		return codes.Unavailable
 	default:
 		return codes.Unknown
 	}
 }
+
+// Violation describes an invalid request field. It is passed as metadata of
+// InvalidArgument errors and reported to clients as a BadRequest field violation.
+type Violation struct {
+	Field       string
+	Description string
+}
+
+// Reason returns the machine readable reason of the code, as in "INVALID_ARGUMENT".
+func Reason(code errs.Code) string {
+	switch code {
+	case Internal:
+		return "INTERNAL"
+	case NotFound:
+		return "NOT_FOUND"
+	case NotChanged:
+		return "NOT_CHANGED"
+	case AccessDenied:
+		return "ACCESS_DENIED"
+	case AlreadyExists:
+		return "ALREADY_EXISTS"
+	case Unauthorized:
+		return "UNAUTHORIZED"
+	case Unauthenticated:
+		return "UNAUTHENTICATED"
+	case InvalidArgument:
+		return "INVALID_ARGUMENT"
+	case Suppressed:
+		return "SUPPRESSED"
+	case Banned:
+		return "BANNED"
+	case NotOwner:
+		return "NOT_OWNER"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Describe returns the message used for errors of the code created without one.
+func Describe(code errs.Code) string {
+	return strings.ToLower(strings.ReplaceAll(Reason(code), "_", " "))
+}
+
+// Wrap wraps the error as errs.Wrap does, keeping its code, message and metadata
+// on the wrapper, where they can be read without going back to the original error.
+// gRPC statuses, such as those of the WhatsApp system, are returned as they are,
+// since the wrapper would hide them from status.FromError.
+func Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	wrapped := errs.Wrap(err, "")
+	if e, ok := err.(*errs.Error); ok {
+		wrapped.Code = e.Code
+		wrapped.Message = e.Message
+		wrapped.Metadata = e.Metadata
+	}
+	return wrapped
+}
+
+// Is reports whether err is an error of the code, such as NotChanged for the
+// rows the repositories did not find.
+func Is(err error, code errs.Code) bool {
+	e, ok := err.(*errs.Error)
+	return ok && e.Code == code
+}